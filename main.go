@@ -1,34 +1,17 @@
 package main
 
 import (
-	"database/sql"
 	"log"
-	"yildizskylab/src/api"
-	"yildizskylab/src/db/sqlc"
-	"yildizskylab/src/util"
+	"os"
+	"yildizskylab/src/cmd"
 
 	_ "github.com/lib/pq"
 )
 
 func main() {
-
-	config, err := util.LoadConfig(".")
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	db, err := sql.Open(config.DBDriver, config.DBSource)
-	if err != nil {
+	if err := cmd.Run(os.Args[1:]); err != nil {
 		log.Fatal(err)
 	}
-
-	err = db.Ping()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	server := api.NewServer(sqlc.New(db), config.Secret)
-	server.Start(config.ServerAddress)
 }
 
 // TODO: Mailing system
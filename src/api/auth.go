@@ -23,7 +23,8 @@ func (s *Server) RequireAuth(c *gin.Context) {
 	authHeader := c.GetHeader("Authorization")
 
 	if authHeader == "" || len(authHeader) < 7 || authHeader[:7] != "Bearer " {
-		c.AbortWithStatus(http.StatusUnauthorized)
+		s.metrics.authFailures.WithLabelValues(authMissingToken).Inc()
+		abortUnauthorized(c, "Missing bearer token")
 		return
 	}
 
@@ -34,15 +35,20 @@ func (s *Server) RequireAuth(c *gin.Context) {
 			return nil, fmt.Errorf("Unexpected signin method: %v", token.Header["alg"])
 		}
 
-		return []byte(s.secret), nil
+		return []byte(s.config.Secret), nil
 	})
 
+	// a malformed token comes back as nil together with the error, so the error is checked first
+	if err != nil {
+		s.metrics.authFailures.WithLabelValues(authInvalidToken).Inc()
+		abortUnauthorized(c, "Invalid token")
+		return
+	}
+
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if float64(time.Now().Unix()) > claims["exp"].(float64) {
-			c.JSON(http.StatusUnauthorized, Response{
-				IsSuccess: false,
-				Message:   err.Error(),
-			})
+		if exp, ok := claims["exp"].(float64); !ok || float64(time.Now().Unix()) > exp {
+			s.metrics.authFailures.WithLabelValues(authInvalidToken).Inc()
+			abortUnauthorized(c, "Token expired")
 			return
 		}
 
@@ -66,7 +72,8 @@ func (s *Server) RequireAuth(c *gin.Context) {
 		}
 
 		if err != nil {
-			c.AbortWithStatus(http.StatusUnauthorized)
+			s.metrics.authFailures.WithLabelValues(authUnknownUser).Inc()
+			abortUnauthorized(c, "Invalid token")
 			return
 		}
 
@@ -74,7 +81,8 @@ func (s *Server) RequireAuth(c *gin.Context) {
 
 		c.Next()
 	} else {
-		c.AbortWithStatus(http.StatusUnauthorized)
+		s.metrics.authFailures.WithLabelValues(authInvalidToken).Inc()
+		abortUnauthorized(c, "Invalid token")
 		return
 	}
 
@@ -85,7 +93,7 @@ func (s *Server) RequireRole(roles []string, f gin.HandlerFunc) gin.HandlerFunc
 	return func(c *gin.Context) {
 		anyUser, ok := c.Get("user")
 		if !ok {
-			c.AbortWithStatus(http.StatusUnauthorized)
+			abortUnauthorized(c, "Missing bearer token")
 			return
 		}
 
@@ -98,6 +106,15 @@ func (s *Server) RequireRole(roles []string, f gin.HandlerFunc) gin.HandlerFunc
 			}
 		}
 
-		c.AbortWithStatus(http.StatusUnauthorized)
+		abortUnauthorized(c, "You are not authorized to do this")
 	}
 }
+
+// abortUnauthorized stops the chain with a 401 that carries the request id like every other error response
+func abortUnauthorized(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, Response{
+		IsSuccess: false,
+		RequestID: requestID(c),
+		Message:   message,
+	})
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"yildizskylab/src/util"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// publicPathPrefixes are readable by any website, so they get the credential-less cors policy
+var publicPathPrefixes = []string{"/images/", "/news/", "/public/"}
+
+// corsMiddleware picks the cors policy by path, it has to run globally
+// because preflight requests do not match any route
+func corsMiddleware(config util.Config) gin.HandlerFunc {
+	private := cors.New(newCORSConfig(config.CORSAllowOrigins, config.CORSAllowCredentials, config))
+	public := cors.New(newCORSConfig(config.CORSPublicAllowOrigins, false, config))
+
+	return func(c *gin.Context) {
+		if isPublicRead(c.Request) {
+			public(c)
+			return
+		}
+
+		private(c)
+	}
+}
+
+func newCORSConfig(origins []string, credentials bool, config util.Config) cors.Config {
+	corsConfig := cors.Config{
+		AllowMethods:     config.CORSAllowMethods,
+		AllowHeaders:     config.CORSAllowHeaders,
+		ExposeHeaders:    []string{"Content-Length", requestIDHeader},
+		AllowCredentials: credentials,
+		AllowWildcard:    true,
+		MaxAge:           12 * time.Hour,
+	}
+
+	if slices.Contains(origins, "*") {
+		corsConfig.AllowAllOrigins = true
+	} else {
+		corsConfig.AllowOrigins = origins
+	}
+
+	return corsConfig
+}
+
+// isPublicRead reports whether the request, or the request a preflight asks for, only reads a public route,
+// uploads to /images and /news still go through the private policy
+func isPublicRead(r *http.Request) bool {
+	method := r.Method
+
+	if method == http.MethodOptions {
+		method = r.Header.Get("Access-Control-Request-Method")
+	}
+
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+
+	for _, prefix := range publicPathPrefixes {
+		if strings.HasPrefix(r.URL.Path+"/", prefix) {
+			return true
+		}
+	}
+
+	return false
+}
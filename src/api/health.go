@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+	"yildizskylab/src/db/migration"
+	"yildizskylab/src/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyTimeout keeps a stuck database from hanging the probe
+const readyTimeout = 2 * time.Second
+
+type readyCheck struct {
+	Name  string `json:"name"`
+	Ok    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type versionResponse struct {
+	Version       string `json:"version"`
+	Commit        string `json:"commit"`
+	BuildTime     string `json:"build_time"`
+	SchemaVersion int64  `json:"schema_version"`
+	SchemaDirty   bool   `json:"schema_dirty"`
+}
+
+// HEALTHZ
+// healthz only reports that the process is serving, it does not touch the database
+func (s *Server) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "ok",
+	})
+}
+
+////////////////////////
+
+// READYZ
+// readyz reports whether the server can take traffic, images are stored in the database
+// so a reachable database also means a reachable storage
+func (s *Server) readyz(c *gin.Context) {
+	if s.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Server is shutting down",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, readyTimeout)
+	defer cancel()
+
+	checks := []readyCheck{
+		{Name: "database"},
+		{Name: "migrations"},
+	}
+
+	for i, check := range []func(context.Context) error{s.query.Ping, s.checkSchemaVersion} {
+		// the reason is only logged, probes are public and errors can carry connection details
+		if err := check(ctx); err != nil {
+			slog.WarnContext(c, "readiness check failed", "check", checks[i].Name, "error", err)
+			checks[i].Error = "check failed"
+			continue
+		}
+
+		checks[i].Ok = true
+	}
+
+	for _, check := range checks {
+		if !check.Ok {
+			c.JSON(http.StatusServiceUnavailable, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Server is not ready",
+				Data:      checks,
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Server is ready",
+		Data:      checks,
+	})
+}
+
+////////////////////////
+
+// VERSION
+func (s *Server) version(c *gin.Context) {
+	response := versionResponse{
+		Version:   util.Version,
+		Commit:    util.Commit,
+		BuildTime: util.BuildTime,
+	}
+
+	// the build information is still useful when the database is down, so the schema version is left at 0
+	ctx, cancel := context.WithTimeout(c, readyTimeout)
+	defer cancel()
+
+	response.SchemaVersion, response.SchemaDirty, _ = s.query.SchemaVersion(ctx)
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Version got successfully",
+		Data:      response,
+	})
+}
+
+////////////////////////
+
+// UTILS
+// checkSchemaVersion reports an error unless every embedded migration is cleanly applied
+func (s *Server) checkSchemaVersion(ctx context.Context) error {
+	expected, err := migration.Latest()
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := s.query.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("schema version %d is dirty", version)
+	}
+
+	if version != int64(expected) {
+		return fmt.Errorf("schema version is %d, expected %d", version, expected)
+	}
+
+	return nil
+}
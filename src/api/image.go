@@ -3,11 +3,15 @@ package api
 import (
 	"bytes"
 	"crypto/rand"
+	"database/sql"
 	"encoding/base64"
+	"errors"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"slices"
+	"strconv"
 	"yildizskylab/src/db/sqlc"
-	"yildizskylab/src/util"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,34 +22,22 @@ func (s *Server) createImage(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
-	srcFile, err := file.Open()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
-		return
-	}
-
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, srcFile); err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+	imageBytes, contentType, ok := s.readImageUpload(c, file)
+	if !ok {
 		return
 	}
-	imageBytes := buf.Bytes()
 
 	userResult, ok := c.Get("user")
 	if !ok {
 		c.JSON(http.StatusInternalServerError, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "User not found",
 		})
 		return
@@ -55,22 +47,18 @@ func (s *Server) createImage(c *gin.Context) {
 	url := genereteUrl()
 
 	savedImage, err := s.query.SaveImage(c, sqlc.SaveImageParams{
-		Type:      file.Header.Get("Content-Type"),
+		Type:      contentType,
 		Name:      file.Filename,
 		Data:      imageBytes,
 		Url:       url,
-		CreatedBy: user.ID,
+		CreatedBy: sql.NullInt32{Int32: user.ID, Valid: true},
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	config, err := util.LoadConfig(".")
-	savedImage.Url = config.Domain + "/images/" + savedImage.Url
+	savedImage.Url = s.imageURL(savedImage.Url)
 
 	c.JSON(http.StatusOK, Response{
 		IsSuccess: true,
@@ -90,6 +78,7 @@ func (s *Server) getImage(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -97,10 +86,15 @@ func (s *Server) getImage(c *gin.Context) {
 
 	image, err := s.query.GetImageByUrl(c, req.ImageUrl)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Image not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -121,3 +115,60 @@ func genereteUrl() string {
 
 	return base64.URLEncoding.EncodeToString(randomBytes)
 }
+
+// imageURL returns the public address of an image saved with the given url
+func (s *Server) imageURL(url string) string {
+	return s.config.Domain + "/images/" + url
+}
+
+// readImageUpload checks an uploaded file against the configured size and type limits and reads it,
+// the type is sniffed from the content instead of trusting the client.
+// It writes the error response itself and reports whether the handler can continue
+func (s *Server) readImageUpload(c *gin.Context, file *multipart.FileHeader) ([]byte, string, bool) {
+	if file.Size > s.config.MaxUploadSize {
+		s.metrics.uploadRejects.WithLabelValues("too_large").Inc()
+		c.JSON(http.StatusRequestEntityTooLarge, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Image can not be larger than " + strconv.FormatInt(s.config.MaxUploadSize, 10) + " bytes",
+		})
+		return nil, "", false
+	}
+
+	srcFile, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Image could not be read",
+		})
+		return nil, "", false
+	}
+	defer srcFile.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(srcFile, s.config.MaxUploadSize)); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Image could not be read",
+		})
+		return nil, "", false
+	}
+
+	contentType := http.DetectContentType(buf.Bytes())
+
+	if !slices.Contains(s.config.UploadAllowedTypes, contentType) {
+		s.metrics.uploadRejects.WithLabelValues("unsupported_type").Inc()
+		c.JSON(http.StatusUnsupportedMediaType, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Unsupported image type " + contentType,
+		})
+		return nil, "", false
+	}
+
+	s.metrics.uploadBytes.Observe(float64(buf.Len()))
+
+	return buf.Bytes(), contentType, true
+}
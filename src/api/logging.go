@@ -0,0 +1,107 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+)
+
+// validRequestID keeps ids coming from clients or proxies short and safe to log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// requestIDMiddleware reuses the X-Request-ID sent by a proxy or creates one, and echoes it back
+func requestIDMiddleware(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+
+	if !validRequestID.MatchString(id) {
+		id = newRequestID()
+	}
+
+	c.Set(requestIDKey, id)
+	c.Header(requestIDHeader, id)
+
+	c.Next()
+}
+
+// requestLogger writes one json line per request once it is handled
+func requestLogger(c *gin.Context) {
+	start := time.Now()
+
+	c.Next()
+
+	status := c.Writer.Status()
+
+	attrs := []any{
+		"request_id", requestID(c),
+		"method", c.Request.Method,
+		"route", c.FullPath(),
+		"path", c.Request.URL.Path,
+		"status", status,
+		"latency_ms", time.Since(start).Milliseconds(),
+		"client_ip", c.ClientIP(),
+		"bytes", c.Writer.Size(),
+	}
+
+	// the user is only known when RequireAuth ran for the route
+	if user, ok := c.Get("user"); ok {
+		attrs = append(attrs, "user_id", user.(sqlc.User).ID)
+	}
+
+	if len(c.Errors) > 0 {
+		attrs = append(attrs, "errors", c.Errors.String())
+	}
+
+	level := slog.LevelInfo
+
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	slog.Log(c, level, "request", attrs...)
+}
+
+// recoverPanic logs a panicking handler with its stack and answers like any other internal error
+func recoverPanic(c *gin.Context, recovered any) {
+	slog.ErrorContext(c, "panic",
+		"request_id", requestID(c),
+		"method", c.Request.Method,
+		"route", c.FullPath(),
+		"error", recovered,
+		"stack", string(debug.Stack()),
+	)
+
+	c.AbortWithStatusJSON(http.StatusInternalServerError, Response{
+		IsSuccess: false,
+		RequestID: requestID(c),
+		Message:   "Internal server error",
+	})
+}
+
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
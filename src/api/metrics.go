@@ -0,0 +1,119 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"time"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "yildizskylab"
+
+// auth failure reasons, kept to a fixed set so the label does not grow
+const (
+	authMissingToken       = "missing_token"
+	authInvalidToken       = "invalid_token"
+	authUnknownUser        = "unknown_user"
+	authInvalidCredentials = "invalid_credentials"
+	authRateLimited        = "rate_limited"
+	authLocked             = "locked"
+)
+
+type metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	uploadBytes     prometheus.Histogram
+	uploadRejects   *prometheus.CounterVec
+	authFailures    *prometheus.CounterVec
+}
+
+// newMetrics registers every collector on its own registry, so building more than one server does not panic
+func newMetrics(store *sqlc.Store) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Handled http requests by route template and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of http requests by route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		uploadBytes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "image_upload_bytes",
+			Help:      "Size of accepted image uploads.",
+			Buckets:   prometheus.ExponentialBuckets(16<<10, 2, 10),
+		}),
+		uploadRejects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "image_upload_rejections_total",
+			Help:      "Rejected image uploads by reason.",
+		}, []string{"reason"}),
+		authFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "auth_failures_total",
+			Help:      "Failed authentications by reason.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.requestDuration,
+		m.uploadBytes,
+		m.uploadRejects,
+		m.authFailures,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	if store != nil {
+		m.registry.MustRegister(collectors.NewDBStatsCollector(store.DB(), metricsNamespace))
+	}
+
+	return m
+}
+
+// instrument counts every request under its route template, raw paths would create a series per id
+func (m *metrics) instrument(c *gin.Context) {
+	start := time.Now()
+
+	c.Next()
+
+	route := c.FullPath()
+
+	if route == "" {
+		route = "unmatched"
+	}
+
+	m.requests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+	m.requestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+}
+
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// requireMetricsToken only lets scrapers that send the configured bearer token through
+func requireMetricsToken(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+
+	return func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+			abortUnauthorized(c, "Invalid metrics token")
+			return
+		}
+
+		c.Next()
+	}
+}
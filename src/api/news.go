@@ -1,13 +1,11 @@
 package api
 
 import (
-	"bytes"
 	"database/sql"
-	"io"
+	"math"
 	"net/http"
 	"time"
 	"yildizskylab/src/db/sqlc"
-	"yildizskylab/src/util"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,6 +21,7 @@ func (s *Server) createNews(c *gin.Context) {
 	if err := c.ShouldBind(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -32,30 +31,16 @@ func (s *Server) createNews(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Cover image is required",
 		})
 		return
 	}
 
-	srcFile, err := file.Open()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
-		return
-	}
-	defer srcFile.Close()
-
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, srcFile); err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   "Unable to read cover image",
-		})
+	imageBytes, contentType, ok := s.readImageUpload(c, file)
+	if !ok {
 		return
 	}
-	imageBytes := buf.Bytes()
 
 	url := genereteUrl()
 
@@ -63,6 +48,7 @@ func (s *Server) createNews(c *gin.Context) {
 	if !ok {
 		c.JSON(http.StatusInternalServerError, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "User not found",
 		})
 		return
@@ -70,29 +56,22 @@ func (s *Server) createNews(c *gin.Context) {
 	user := userResult.(sqlc.User)
 
 	savedImage, err := s.query.SaveImage(c, sqlc.SaveImageParams{
-		Type:      file.Header.Get("Content-Type"),
+		Type:      contentType,
 		Name:      file.Filename,
 		Data:      imageBytes,
 		Url:       url,
-		CreatedBy: user.ID,
+		CreatedBy: sql.NullInt32{Int32: user.ID, Valid: true},
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Unable to save cover image",
 		})
 		return
 	}
 
-	config, err := util.LoadConfig(".")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   "Config load error",
-		})
-		return
-	}
-	savedImage.Url = config.Domain + "/images/" + savedImage.Url
+	savedImage.Url = s.imageURL(savedImage.Url)
 
 	news, err := s.query.CreateNews(c, sqlc.CreateNewsParams{
 		Title:        req.Title,
@@ -104,6 +83,7 @@ func (s *Server) createNews(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Unable to save news",
 		})
 		return
@@ -116,11 +96,6 @@ func (s *Server) createNews(c *gin.Context) {
 	})
 }
 
-type getAllNewsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
-}
-
 type NewsWithDetails struct {
 	ID          int       `json:"id"`
 	Title       string    `json:"title"`
@@ -145,34 +120,52 @@ type NewsWithDetails struct {
 
 func (s *Server) getAllNews(c *gin.Context) {
 
-	var req getAllNewsRequest
+	var req pageRequest
 
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
-	news, err := s.query.GetNewsWithDetails(c, sqlc.GetNewsWithDetailsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	news, page, ok := listPage(c, req, pageQueries[sqlc.GetNewsWithDetailsRow]{
+		count: func() (int64, error) {
+			return s.query.CountNewsWithDetails(c)
+		},
+		byOffset: func(limit, offset int32) ([]sqlc.GetNewsWithDetailsRow, error) {
+			return s.query.GetNewsWithDetails(c, sqlc.GetNewsWithDetailsParams{
+				Limit:  limit,
+				Offset: offset,
+			})
+		},
+		afterCursor: func(cursor, limit int32) ([]sqlc.GetNewsWithDetailsRow, error) {
+			// news are listed newest first, so the cursor points below the last id
+			if cursor == 0 {
+				cursor = math.MaxInt32
+			}
+
+			rows, err := s.query.GetNewsWithDetailsBeforeCursor(c, sqlc.GetNewsWithDetailsBeforeCursorParams{
+				ID:    cursor,
+				Limit: limit,
+			})
+
+			news := make([]sqlc.GetNewsWithDetailsRow, len(rows))
+
+			for i, row := range rows {
+				news[i] = sqlc.GetNewsWithDetailsRow(row)
+			}
+
+			return news, err
+		},
+		id: func(news sqlc.GetNewsWithDetailsRow) int32 {
+			return news.ID
+		},
 	})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
-		return
-	}
 
-	config, err := util.LoadConfig(".")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   "Config load error",
-		})
+	if !ok {
 		return
 	}
 
@@ -189,7 +182,7 @@ func (s *Server) getAllNews(c *gin.Context) {
 				Type string `json:"type"`
 			}{
 				ID:   int(n.ImageID),
-				URL:  config.Domain + "/images/" + n.ImageUrl,
+				URL:  s.imageURL(n.ImageUrl),
 				Type: n.ImageType,
 			},
 			CreatedBy: struct {
@@ -211,9 +204,10 @@ func (s *Server) getAllNews(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, Response{
-		IsSuccess: true,
-		Message:   "News got successfully",
-		Data:      newsList,
+		IsSuccess:  true,
+		Message:    "News got successfully",
+		Data:       newsList,
+		Pagination: page,
 	})
 }
 
@@ -228,6 +222,7 @@ func (s *Server) getNews(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -239,23 +234,12 @@ func (s *Server) getNews(c *gin.Context) {
 		if err == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, Response{
 				IsSuccess: false,
+				RequestID: requestID(c),
 				Message:   "News not found",
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
-		return
-	}
-
-	config, err := util.LoadConfig(".")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   "Config load error",
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -273,7 +257,7 @@ func (s *Server) getNews(c *gin.Context) {
 				Type string `json:"type"`
 			}{
 				ID:   int(n.ImageID),
-				URL:  config.Domain + "/images/" + n.ImageUrl,
+				URL:  s.imageURL(n.ImageUrl),
 				Type: n.ImageType,
 			},
 			CreatedBy: struct {
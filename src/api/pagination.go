@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+var (
+	errInvalidCursor  = errors.New("invalid cursor")
+	errPageOutOfRange = errors.New("page_id is out of range")
+)
+
+// pageRequest is the query of the lists that can be read by page number or after a cursor
+type pageRequest struct {
+	PageID   int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor" binding:"excluded_with=PageID"`
+}
+
+// cursorRequest is the query of the lists that can only be read after a cursor
+type cursorRequest struct {
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
+}
+
+// pageQueries are the queries behind a list, byOffset reads a numbered page
+// and afterCursor reads the rows following the cursor, 0 when there is none.
+// byOffset can be left out of the lists that are read with listAfterCursor
+type pageQueries[T any] struct {
+	count       func() (int64, error)
+	byOffset    func(limit, offset int32) ([]T, error)
+	afterCursor func(cursor, limit int32) ([]T, error)
+	id          func(T) int32
+}
+
+// listPage reads the requested page of a list together with its pagination metadata.
+// It writes the error response itself and reports whether the handler can continue
+func listPage[T any](c *gin.Context, req pageRequest, q pageQueries[T]) ([]T, *pagination, bool) {
+	cursor, err := decodeCursor(req.Cursor)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return nil, nil, false
+	}
+
+	pageSize := pageSizeOrDefault(req.PageSize)
+
+	// computed in int64, a large page_id would otherwise wrap around to a negative offset
+	var offset int64
+
+	if req.PageID != 0 {
+		offset = (int64(req.PageID) - 1) * int64(pageSize)
+	}
+
+	if offset > math.MaxInt32 {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   errPageOutOfRange.Error(),
+		})
+		return nil, nil, false
+	}
+
+	total, err := q.count()
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return nil, nil, false
+	}
+
+	var items []T
+	var hasMore bool
+
+	if req.PageID != 0 {
+		items, err = q.byOffset(pageSize, int32(offset))
+
+		hasMore = offset+int64(len(items)) < total
+	} else {
+		// fetch one extra row to know if there is a next page
+		items, err = q.afterCursor(cursor, pageSize+1)
+
+		if hasMore = len(items) > int(pageSize); hasMore {
+			items = items[:pageSize]
+		}
+	}
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return nil, nil, false
+	}
+
+	var lastID int32
+
+	if len(items) > 0 {
+		lastID = q.id(items[len(items)-1])
+	}
+
+	return items, newPagination(total, lastID, hasMore), true
+}
+
+// listAfterCursor is listPage for the lists without numbered pages
+func listAfterCursor[T any](c *gin.Context, req cursorRequest, q pageQueries[T]) ([]T, *pagination, bool) {
+	return listPage(c, pageRequest{PageSize: req.PageSize, Cursor: req.Cursor}, q)
+}
+
+type pagination struct {
+	Total      int64  `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// newPagination builds the pagination metadata of a list response,
+// the next cursor is only set when there are more items to fetch
+func newPagination(total int64, lastID int32, hasMore bool) *pagination {
+	p := &pagination{
+		Total:   total,
+		HasMore: hasMore,
+	}
+
+	if hasMore {
+		p.NextCursor = encodeCursor(lastID)
+	}
+
+	return p
+}
+
+func pageSizeOrDefault(pageSize int32) int32 {
+	if pageSize == 0 {
+		return defaultPageSize
+	}
+
+	return pageSize
+}
+
+// encodeCursor hides the id of the last returned item behind an opaque token
+func encodeCursor(id int32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(int64(id), 10)))
+}
+
+// decodeCursor returns the id stored in the cursor, an empty cursor means the first page
+func decodeCursor(cursor string) (int32, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil || id < 1 {
+		return 0, errInvalidCursor
+	}
+
+	return int32(id), nil
+}
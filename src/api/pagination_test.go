@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, id := range []int32{1, 42, math.MaxInt32} {
+		got, err := decodeCursor(encodeCursor(id))
+
+		if err != nil {
+			t.Fatalf("decodeCursor(encodeCursor(%d)): %v", id, err)
+		}
+
+		if got != id {
+			t.Errorf("decodeCursor(encodeCursor(%d)) = %d", id, got)
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+		want   int32
+		err    error
+	}{
+		{"empty is the first page", "", 0, nil},
+		{"not base64", "not a cursor!", 0, errInvalidCursor},
+		{"not a number", encodeRaw("abc"), 0, errInvalidCursor},
+		{"zero", encodeRaw("0"), 0, errInvalidCursor},
+		{"negative", encodeRaw("-5"), 0, errInvalidCursor},
+		{"larger than int32", encodeRaw("2147483648"), 0, errInvalidCursor},
+		{"padded base64", "MTA=", 0, errInvalidCursor},
+		{"valid", encodeRaw("10"), 10, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.cursor)
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("decodeCursor(%q) error = %v, want %v", tt.cursor, err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Errorf("decodeCursor(%q) = %d, want %d", tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      int64
+		lastID     int32
+		hasMore    bool
+		nextCursor string
+	}{
+		{"empty list", 0, 0, false, ""},
+		{"last page", 25, 25, false, ""},
+		{"more pages", 25, 10, true, encodeCursor(10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPagination(tt.total, tt.lastID, tt.hasMore)
+
+			if p.Total != tt.total || p.HasMore != tt.hasMore || p.NextCursor != tt.nextCursor {
+				t.Errorf("newPagination(%d, %d, %t) = %+v, want next cursor %q", tt.total, tt.lastID, tt.hasMore, *p, tt.nextCursor)
+			}
+		})
+	}
+}
+
+func TestPageSizeOrDefault(t *testing.T) {
+	if got := pageSizeOrDefault(0); got != defaultPageSize {
+		t.Errorf("pageSizeOrDefault(0) = %d, want %d", got, defaultPageSize)
+	}
+
+	if got := pageSizeOrDefault(25); got != 25 {
+		t.Errorf("pageSizeOrDefault(25) = %d, want 25", got)
+	}
+}
+
+func TestListPage(t *testing.T) {
+	// ids 1 to 25, read back by offset or after a cursor like the list queries do
+	const total = 25
+
+	queries := func(offsets, cursors *[][2]int32) pageQueries[int32] {
+		return pageQueries[int32]{
+			count: func() (int64, error) {
+				return total, nil
+			},
+			byOffset: func(limit, offset int32) ([]int32, error) {
+				*offsets = append(*offsets, [2]int32{limit, offset})
+				return idRange(offset+1, limit), nil
+			},
+			afterCursor: func(cursor, limit int32) ([]int32, error) {
+				*cursors = append(*cursors, [2]int32{cursor, limit})
+				return idRange(cursor+1, limit), nil
+			},
+			id: func(id int32) int32 {
+				return id
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        pageRequest
+		status     int
+		items      []int32
+		hasMore    bool
+		nextCursor string
+		offsets    [][2]int32
+		cursors    [][2]int32
+	}{
+		{
+			name:       "first page after no cursor",
+			req:        pageRequest{},
+			items:      idRange(1, 10),
+			hasMore:    true,
+			nextCursor: encodeCursor(10),
+			cursors:    [][2]int32{{0, 11}},
+		},
+		{
+			name:    "last page after a cursor",
+			req:     pageRequest{PageSize: 10, Cursor: encodeCursor(20)},
+			items:   idRange(21, 5),
+			hasMore: false,
+			cursors: [][2]int32{{20, 11}},
+		},
+		{
+			name:       "numbered page",
+			req:        pageRequest{PageID: 2, PageSize: 10},
+			items:      idRange(11, 10),
+			hasMore:    true,
+			nextCursor: encodeCursor(20),
+			offsets:    [][2]int32{{10, 10}},
+		},
+		{
+			name:    "last numbered page",
+			req:     pageRequest{PageID: 3, PageSize: 10},
+			items:   idRange(21, 5),
+			hasMore: false,
+			offsets: [][2]int32{{10, 20}},
+		},
+		{
+			name:   "invalid cursor",
+			req:    pageRequest{Cursor: "not a cursor!"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "offset beyond int32",
+			req:    pageRequest{PageID: math.MaxInt32, PageSize: 100},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var offsets, cursors [][2]int32
+
+			items, page, ok := listPage(c, tt.req, queries(&offsets, &cursors))
+
+			if tt.status != 0 {
+				if ok || w.Code != tt.status {
+					t.Fatalf("listPage(%+v) = ok %t with status %d, want status %d", tt.req, ok, w.Code, tt.status)
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("listPage(%+v) failed with status %d: %s", tt.req, w.Code, w.Body.String())
+			}
+
+			if !slices.Equal(items, tt.items) {
+				t.Errorf("items = %v, want %v", items, tt.items)
+			}
+
+			if page.Total != total || page.HasMore != tt.hasMore || page.NextCursor != tt.nextCursor {
+				t.Errorf("pagination = %+v, want has_more %t and next cursor %q", *page, tt.hasMore, tt.nextCursor)
+			}
+
+			if !slices.Equal(offsets, tt.offsets) || !slices.Equal(cursors, tt.cursors) {
+				t.Errorf("byOffset calls %v and afterCursor calls %v, want %v and %v", offsets, cursors, tt.offsets, tt.cursors)
+			}
+		})
+	}
+}
+
+// idRange returns up to n ids starting at from that do not pass 25, the size of the list in TestListPage
+func idRange(from, n int32) []int32 {
+	ids := []int32{}
+
+	for id := from; id < from+n && id <= 25; id++ {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// encodeRaw encodes a cursor the way encodeCursor does, but around any content
+func encodeRaw(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
@@ -4,15 +4,24 @@ import (
 	"database/sql"
 	"net/http"
 	"strconv"
+	"time"
 	"yildizskylab/src/db/sqlc"
 
 	"github.com/gin-gonic/gin"
 )
 
 // CREATE PROJECT
+const (
+	projectPolicyAnyone    = "anyone"
+	projectPolicyTeamLeads = "team_leads"
+	projectPolicyAdmins    = "admins"
+	projectPolicyApproval  = "approval"
+)
+
 type createProjectRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	TeamID      int32  `json:"team_id" binding:"omitempty,min=1"`
 }
 
 func (s *Server) createProject(c *gin.Context) {
@@ -22,27 +31,129 @@ func (s *Server) createProject(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
-	project, err := s.query.CreateProject(c, sqlc.CreateProjectParams{
-		Name:        req.Name,
-		Description: req.Description,
+	user := c.MustGet("user").(sqlc.User)
+
+	approvalStatus, ok := s.checkProjectCreationPolicy(c, user)
+	if !ok {
+		return
+	}
+
+	if req.TeamID != 0 {
+		_, err := s.query.GetTeam(c, req.TeamID)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+
+		if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
+			c.JSON(http.StatusForbidden, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "You are not authorized to add project to this team",
+			})
+			return
+		}
+	}
+
+	result, err := s.query.CreateProjectTx(c, sqlc.CreateProjectTxParams{
+		Name:           req.Name,
+		Description:    req.Description,
+		CreatorID:      user.ID,
+		ApprovalStatus: approvalStatus,
+		TeamID:         req.TeamID,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	message := "Project created successfully"
+	if approvalStatus == "pending" {
+		message = "Project created successfully and is waiting for approval"
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   message,
+		Data:      result,
 	})
+}
+
+////////////////////////
+
+// GET PENDING PROJECTS
+func (s *Server) getPendingProjects(c *gin.Context) {
+	projects, err := s.query.GetPendingProjects(c)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Pending projects got successfully",
+		Data:      projects,
+	})
+}
+
+////////////////////////
+
+// APPROVE PROJECT
+func (s *Server) approveProject(c *gin.Context) {
+	s.reviewProject(c, "approved", "Project approved successfully")
+}
+
+// REJECT PROJECT
+func (s *Server) rejectProject(c *gin.Context) {
+	s.reviewProject(c, "rejected", "Project rejected successfully")
+}
+
+func (s *Server) reviewProject(c *gin.Context, status string, message string) {
+	var req getProjectRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
+	project, err := s.query.ReviewProject(c, sqlc.ReviewProjectParams{
+		ID:             req.ID,
+		ApprovalStatus: status,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Pending project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, Response{
 		IsSuccess: true,
-		Message:   "Project created successfully",
+		Message:   message,
 		Data:      project,
 	})
 }
@@ -55,11 +166,15 @@ type getProjectRequest struct {
 }
 
 type getProjectResponse struct {
-	Id          int32                `json:"id"`
-	Name        string               `json:"name"`
-	Description string               `json:"description"`
-	ProjectLead []returnUserResponse `json:"project_lead"`
-	Teams       []sqlc.Team          `json:"teams"`
+	Id          int32                      `json:"id"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Status      string                     `json:"status"`
+	StartDate   *time.Time                 `json:"start_date"`
+	EndDate     *time.Time                 `json:"end_date"`
+	ProjectLead []returnUserResponse       `json:"project_lead"`
+	Teams       []sqlc.Team                `json:"teams"`
+	Milestones  []projectMilestoneResponse `json:"milestones"`
 }
 
 func (s *Server) getProject(c *gin.Context) {
@@ -68,6 +183,7 @@ func (s *Server) getProject(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -79,83 +195,42 @@ func (s *Server) getProject(c *gin.Context) {
 		if err == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, Response{
 				IsSuccess: false,
+				RequestID: requestID(c),
 				Message:   "Project not found",
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	leadsIds, err := s.query.GetProjectLeadByProjectId(c, project.ID)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
+	if ok := s.checkIfUserIsProjectLead(c, project.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
-			Message:   err.Error(),
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see this team",
 		})
 		return
 	}
 
-	var leads []returnUserResponse
-
-	for _, leadId := range leadsIds {
-		lead, err := s.query.GetUserWithNoDetails(c, leadId)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, Response{
-				IsSuccess: false,
-				Message:   err.Error(),
-			})
-			return
-		}
-
-		leadToReturn := returnUserResponse{
-			Id:              lead.ID,
-			Name:            lead.Name,
-			LastName:        lead.LastName,
-			Email:           lead.Email,
-			TelephoneNumber: lead.TelephoneNumber,
-			University:      lead.University,
-			Department:      lead.Department,
-			Role:            lead.Role,
-			DateOfBirth:     lead.DateOfBirth,
-		}
+	leads, err := s.query.GetProjectLeadsWithDetails(c, project.ID)
 
-		leads = append(leads, leadToReturn)
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
 	}
 
-	teamIds, err := s.query.GetProjectTeamByProjectId(c, project.ID)
+	teams, err := s.query.GetTeamsByProjectId(c, project.ID)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	var teams []sqlc.Team
+	milestones, err := s.query.GetProjectMilestonesByProjectId(c, project.ID)
 
-	for _, teamId := range teamIds {
-		team, err := s.query.GetTeam(c, teamId)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, Response{
-				IsSuccess: false,
-				Message:   err.Error(),
-			})
-			return
-		}
-		teams = append(teams, team)
-	}
-
-	if ok := s.checkIfUserIsProjectLead(c, project.ID); !ok {
-		c.JSON(http.StatusForbidden, Response{
-			IsSuccess: false,
-			Message:   "You are not authorized to see this team",
-		})
+	if err != nil {
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -166,8 +241,12 @@ func (s *Server) getProject(c *gin.Context) {
 			Id:          project.ID,
 			Name:        project.Name,
 			Description: project.Description,
-			ProjectLead: leads,
+			Status:      project.Status,
+			StartDate:   nullTimeToPointer(project.StartDate),
+			EndDate:     nullTimeToPointer(project.EndDate),
+			ProjectLead: newReturnUserResponses(leads),
 			Teams:       teams,
+			Milestones:  newProjectMilestoneResponses(milestones),
 		},
 	})
 }
@@ -175,39 +254,49 @@ func (s *Server) getProject(c *gin.Context) {
 ////////////////////////
 
 // GET ALL PROJECTS
-type getAllProjectsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
-}
 
 func (s *Server) getAllProjects(c *gin.Context) {
-	var req getAllProjectsRequest
+	var req pageRequest
 
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
-	projects, err := s.query.GetAllProjects(c, sqlc.GetAllProjectsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	projects, page, ok := listPage(c, req, pageQueries[sqlc.Project]{
+		count: func() (int64, error) {
+			return s.query.CountProjects(c)
+		},
+		byOffset: func(limit, offset int32) ([]sqlc.Project, error) {
+			return s.query.GetAllProjects(c, sqlc.GetAllProjectsParams{
+				Limit:  limit,
+				Offset: offset,
+			})
+		},
+		afterCursor: func(cursor, limit int32) ([]sqlc.Project, error) {
+			return s.query.GetAllProjectsAfterCursor(c, sqlc.GetAllProjectsAfterCursorParams{
+				ID:    cursor,
+				Limit: limit,
+			})
+		},
+		id: func(project sqlc.Project) int32 {
+			return project.ID
+		},
 	})
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+	if !ok {
 		return
 	}
 
 	c.JSON(http.StatusOK, Response{
-		IsSuccess: true,
-		Message:   "Projects got successfully",
-		Data:      projects,
+		IsSuccess:  true,
+		Message:    "Projects got successfully",
+		Data:       projects,
+		Pagination: page,
 	})
 }
 
@@ -215,8 +304,10 @@ func (s *Server) getAllProjects(c *gin.Context) {
 
 // UPDATE PROJECT
 type updateProjectRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description" `
+	Name        *string    `json:"name"`
+	Description *string    `json:"description" `
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
 }
 
 func (s *Server) updateProject(c *gin.Context) {
@@ -230,8 +321,10 @@ func (s *Server) updateProject(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
+		return
 	}
 
 	id = int32(i)
@@ -241,6 +334,7 @@ func (s *Server) updateProject(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -249,18 +343,29 @@ func (s *Server) updateProject(c *gin.Context) {
 	if ok := s.checkIfUserIsProjectLead(c, id); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to see this team",
 		})
 		return
 	}
 
+	if ok := s.checkIfProjectIsApproved(c, id); !ok {
+		return
+	}
+
 	updatedProject, err := s.query.GetProject(c, id)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
 	}
 
 	if req.Name != nil {
@@ -272,17 +377,34 @@ func (s *Server) updateProject(c *gin.Context) {
 		updatedProject.Description = *req.Description
 	}
 
+	if req.StartDate != nil {
+		updatedProject.StartDate = sql.NullTime{Time: *req.StartDate, Valid: true}
+	}
+
+	if req.EndDate != nil {
+		updatedProject.EndDate = sql.NullTime{Time: *req.EndDate, Valid: true}
+	}
+
+	if updatedProject.StartDate.Valid && updatedProject.EndDate.Valid && updatedProject.EndDate.Time.Before(updatedProject.StartDate.Time) {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "End date can not be before start date",
+		})
+		return
+	}
+
 	project, err := s.query.UpdateProject(c, sqlc.UpdateProjectParams{
 		ID:          id,
 		Name:        updatedProject.Name,
 		Description: updatedProject.Description,
+		StartDate:   updatedProject.StartDate,
+		EndDate:     updatedProject.EndDate,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, Response{
@@ -305,6 +427,7 @@ func (s *Server) deleteProject(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -313,18 +436,24 @@ func (s *Server) deleteProject(c *gin.Context) {
 	if ok := s.checkIfUserIsProjectLead(c, req.ID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to see this team",
 		})
 		return
 	}
 
-	err := s.query.DeleteProject(c, req.ID)
+	err := s.query.DeleteProjectTx(c, req.ID)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -348,6 +477,7 @@ func (s *Server) addProjectMember(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -358,6 +488,7 @@ func (s *Server) addProjectMember(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Project not found",
 		})
 		return
@@ -368,6 +499,7 @@ func (s *Server) addProjectMember(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "User not found",
 		})
 		return
@@ -376,11 +508,16 @@ func (s *Server) addProjectMember(c *gin.Context) {
 	if ok := s.checkIfUserIsProjectLead(c, project.ID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to see this team",
 		})
 		return
 	}
 
+	if ok := s.checkIfProjectIsApproved(c, project.ID); !ok {
+		return
+	}
+
 	projectMember, err := s.query.CreateProjectMember(c, sqlc.CreateProjectMemberParams{
 		ProjectID: req.ProjectID,
 		UserID:    req.UserID,
@@ -388,10 +525,7 @@ func (s *Server) addProjectMember(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -410,6 +544,7 @@ func (s *Server) addProjectLead(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -420,6 +555,7 @@ func (s *Server) addProjectLead(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Project not found",
 		})
 		return
@@ -430,6 +566,7 @@ func (s *Server) addProjectLead(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "User not found",
 		})
 		return
@@ -438,11 +575,16 @@ func (s *Server) addProjectLead(c *gin.Context) {
 	if ok := s.checkIfUserIsProjectLead(c, project.ID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to see this team",
 		})
 		return
 	}
 
+	if ok := s.checkIfProjectIsApproved(c, project.ID); !ok {
+		return
+	}
+
 	projectMember, err := s.query.CreateProjectMember(c, sqlc.CreateProjectMemberParams{
 		ProjectID: req.ProjectID,
 		UserID:    req.UserID,
@@ -450,10 +592,7 @@ func (s *Server) addProjectLead(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -476,6 +615,7 @@ func (s *Server) removeProjectMember(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -484,27 +624,157 @@ func (s *Server) removeProjectMember(c *gin.Context) {
 	if ok := s.checkIfUserIsProjectLead(c, req.ProjectID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to see this team",
 		})
 		return
 	}
 
-	err := s.query.DeleteProjectMember(c, sqlc.DeleteProjectMemberParams{
+	if ok := s.checkIfProjectIsApproved(c, req.ProjectID); !ok {
+		return
+	}
+
+	err := s.query.RemoveProjectMemberTx(c, sqlc.DeleteProjectMemberParams{
 		ProjectID: req.ProjectID,
 		UserID:    req.UserID,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
+		if err == sqlc.ErrLastLead {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "The last lead of a project can not be removed, transfer the leadership first",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Project member removed successfully",
+	})
+}
+
+////////////////////////
+
+// CHANGE PROJECT MEMBER ROLE
+type changeProjectMemberRoleRequest struct {
+	ProjectID int32  `json:"project_id" binding:"required,min=1"`
+	UserID    int32  `json:"user_id" binding:"required,min=1"`
+	Role      string `json:"role" binding:"required,oneof=lead member"`
+}
+
+func (s *Server) changeProjectMemberRole(c *gin.Context) {
+	var req changeProjectMemberRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
+	if ok := s.checkIfUserIsProjectLead(c, req.ProjectID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to change roles in this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, req.ProjectID); !ok {
+		return
+	}
+
+	member, err := s.query.ChangeProjectMemberRoleTx(c, sqlc.ChangeProjectMemberRoleTxParams{
+		ProjectID: req.ProjectID,
+		UserID:    req.UserID,
+		Role:      req.Role,
+	})
+
+	if err != nil {
+		s.leadershipErrorResponse(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, Response{
 		IsSuccess: true,
-		Message:   "Project member removed successfully",
+		Message:   "Project member role changed successfully",
+		Data:      member,
+	})
+}
+
+////////////////////////
+
+// TRANSFER PROJECT LEADERSHIP
+type transferProjectLeadershipRequest struct {
+	ProjectID  int32 `json:"project_id" binding:"required,min=1"`
+	FromUserID int32 `json:"from_user_id" binding:"omitempty,min=1"`
+	ToUserID   int32 `json:"to_user_id" binding:"required,min=1"`
+}
+
+func (s *Server) transferProjectLeadership(c *gin.Context) {
+	var req transferProjectLeadershipRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, req.ProjectID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to transfer the leadership of this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, req.ProjectID); !ok {
+		return
+	}
+
+	// leads hand over their own leadership, admins may hand over anyone's
+	user := c.MustGet("user").(sqlc.User)
+
+	if req.FromUserID == 0 || user.Role != admin {
+		req.FromUserID = user.ID
+	}
+
+	if req.FromUserID == req.ToUserID {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Leadership can not be transferred to the same user",
+		})
+		return
+	}
+
+	result, err := s.query.TransferProjectLeadershipTx(c, sqlc.TransferProjectLeadershipTxParams{
+		ProjectID:  req.ProjectID,
+		FromUserID: req.FromUserID,
+		ToUserID:   req.ToUserID,
+	})
+
+	if err != nil {
+		s.leadershipErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Project leadership transferred successfully",
+		Data:      result,
 	})
 }
 
@@ -538,3 +808,98 @@ func (s *Server) checkIfUserIsProjectLead(c *gin.Context, projectID int32) bool
 
 	return false
 }
+
+// checkProjectCreationPolicy checks if the user may create a project and returns its approval status,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) checkProjectCreationPolicy(c *gin.Context, user sqlc.User) (string, bool) {
+	if user.Role == admin {
+		return "approved", true
+	}
+
+	switch s.config.ProjectCreationPolicy {
+	case projectPolicyAnyone:
+		return "approved", true
+	case projectPolicyApproval:
+		return "pending", true
+	case projectPolicyTeamLeads:
+		isLead, err := s.query.IsLeadOfAnyTeam(c, user.ID)
+
+		if err != nil {
+			dbErrorResponse(c, err)
+			return "", false
+		}
+
+		if isLead {
+			return "approved", true
+		}
+	case projectPolicyAdmins:
+	}
+
+	// admins policy and unknown policies only let admins through
+	c.JSON(http.StatusForbidden, Response{
+		IsSuccess: false,
+		RequestID: requestID(c),
+		Message:   "You are not authorized to create projects",
+	})
+	return "", false
+}
+
+// checkIfProjectIsApproved keeps pending and rejected projects read-only until an admin approves them,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) checkIfProjectIsApproved(c *gin.Context, projectID int32) bool {
+	status, err := s.query.GetProjectApprovalStatus(c, projectID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return false
+		}
+		dbErrorResponse(c, err)
+		return false
+	}
+
+	if status != "approved" {
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Project is not approved, it can not be changed",
+		})
+		return false
+	}
+
+	return true
+}
+
+// checkIfUserIsProjectMember checks if the user is a member or a lead of the project
+func (s *Server) checkIfUserIsProjectMember(c *gin.Context, projectID int32) bool {
+
+	anyUser, ok := c.Get("user")
+	if !ok {
+		return false
+	}
+
+	user := anyUser.(sqlc.User)
+
+	if user.Role == "admin" {
+		return true
+	}
+
+	_, err := s.query.GetProjectMember(c, sqlc.GetProjectMemberParams{
+		ProjectID: projectID,
+		UserID:    user.ID,
+	})
+
+	return err == nil
+}
+
+func nullTimeToPointer(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
@@ -0,0 +1,445 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	projectStatusProposed  = "proposed"
+	projectStatusActive    = "active"
+	projectStatusPaused    = "paused"
+	projectStatusCompleted = "completed"
+	projectStatusCancelled = "cancelled"
+)
+
+// projectStatusTransitions lists the statuses a project can move to from its current status,
+// completed and cancelled projects are final
+var projectStatusTransitions = map[string][]string{
+	projectStatusProposed:  {projectStatusActive, projectStatusCancelled},
+	projectStatusActive:    {projectStatusPaused, projectStatusCompleted, projectStatusCancelled},
+	projectStatusPaused:    {projectStatusActive, projectStatusCancelled},
+	projectStatusCompleted: {},
+	projectStatusCancelled: {},
+}
+
+type projectMilestoneResponse struct {
+	Id          int32      `json:"id"`
+	ProjectID   int32      `json:"project_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueDate     time.Time  `json:"due_date"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// UPDATE PROJECT STATUS
+type updateProjectStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=proposed active paused completed cancelled"`
+}
+
+func (s *Server) updateProjectStatus(c *gin.Context) {
+	var uri getProjectRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req updateProjectStatusRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, uri.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to change the status of this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, uri.ID); !ok {
+		return
+	}
+
+	project, err := s.query.GetProject(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if !canTransitionProjectStatus(project.Status, req.Status) {
+		c.JSON(http.StatusUnprocessableEntity, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "A project that is " + project.Status + " can not become " + req.Status,
+		})
+		return
+	}
+
+	// the lifecycle fills in the dates the leads did not set themselves
+	now := time.Now()
+
+	if req.Status == projectStatusActive && !project.StartDate.Valid {
+		project.StartDate = sql.NullTime{Time: now, Valid: true}
+	}
+
+	if (req.Status == projectStatusCompleted || req.Status == projectStatusCancelled) && !project.EndDate.Valid {
+		project.EndDate = sql.NullTime{Time: now, Valid: true}
+	}
+
+	// the update only matches while the status is still the one checked above,
+	// so two concurrent changes can not make a transition the lifecycle forbids
+	updated, err := s.query.UpdateProjectStatus(c, sqlc.UpdateProjectStatusParams{
+		ID:         project.ID,
+		FromStatus: project.Status,
+		Status:     req.Status,
+		StartDate:  project.StartDate,
+		EndDate:    project.EndDate,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project status was changed by someone else, try again",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Project status updated successfully",
+		Data:      updated,
+	})
+}
+
+////////////////////////
+
+// CREATE PROJECT MILESTONE
+type createProjectMilestoneRequest struct {
+	Title       string    `json:"title" binding:"required"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date" binding:"required"`
+}
+
+func (s *Server) createProjectMilestone(c *gin.Context) {
+	var uri getProjectRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req createProjectMilestoneRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	_, err := s.query.GetProject(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, uri.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to add milestones to this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, uri.ID); !ok {
+		return
+	}
+
+	milestone, err := s.query.CreateProjectMilestone(c, sqlc.CreateProjectMilestoneParams{
+		ProjectID:   uri.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Milestone created successfully",
+		Data:      newProjectMilestoneResponse(milestone),
+	})
+}
+
+////////////////////////
+
+// GET PROJECT MILESTONES
+func (s *Server) getProjectMilestones(c *gin.Context) {
+	var req getProjectRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectMember(c, req.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see milestones of this project",
+		})
+		return
+	}
+
+	milestones, err := s.query.GetProjectMilestonesByProjectId(c, req.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Milestones got successfully",
+		Data:      newProjectMilestoneResponses(milestones),
+	})
+}
+
+////////////////////////
+
+// UPDATE PROJECT MILESTONE
+type projectMilestoneUriRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type updateProjectMilestoneRequest struct {
+	Title       *string    `json:"title"`
+	Description *string    `json:"description"`
+	DueDate     *time.Time `json:"due_date"`
+	Completed   *bool      `json:"completed"`
+}
+
+func (s *Server) updateProjectMilestone(c *gin.Context) {
+	var uri projectMilestoneUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req updateProjectMilestoneRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	milestone, ok := s.getManageableProjectMilestone(c, uri.ID)
+	if !ok {
+		return
+	}
+
+	if req.Title != nil {
+		milestone.Title = *req.Title
+	}
+
+	if req.Description != nil {
+		milestone.Description = *req.Description
+	}
+
+	if req.DueDate != nil {
+		milestone.DueDate = *req.DueDate
+	}
+
+	if req.Completed != nil {
+		if !*req.Completed {
+			milestone.CompletedAt = sql.NullTime{}
+		} else if !milestone.CompletedAt.Valid {
+			milestone.CompletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		}
+	}
+
+	updated, err := s.query.UpdateProjectMilestone(c, sqlc.UpdateProjectMilestoneParams{
+		ID:          milestone.ID,
+		Title:       milestone.Title,
+		Description: milestone.Description,
+		DueDate:     milestone.DueDate,
+		CompletedAt: milestone.CompletedAt,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Milestone updated successfully",
+		Data:      newProjectMilestoneResponse(updated),
+	})
+}
+
+////////////////////////
+
+// DELETE PROJECT MILESTONE
+func (s *Server) deleteProjectMilestone(c *gin.Context) {
+	var uri projectMilestoneUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	milestone, ok := s.getManageableProjectMilestone(c, uri.ID)
+	if !ok {
+		return
+	}
+
+	err := s.query.DeleteProjectMilestone(c, milestone.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Milestone deleted successfully",
+	})
+}
+
+////////////////////////
+
+// UTILS
+func canTransitionProjectStatus(from string, to string) bool {
+	for _, status := range projectStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getManageableProjectMilestone loads a milestone and checks that the current user leads its approved project,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) getManageableProjectMilestone(c *gin.Context, id int32) (sqlc.ProjectMilestone, bool) {
+	milestone, err := s.query.GetProjectMilestone(c, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Milestone not found",
+			})
+			return milestone, false
+		}
+		dbErrorResponse(c, err)
+		return milestone, false
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, milestone.ProjectID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to manage milestones of this project",
+		})
+		return milestone, false
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, milestone.ProjectID); !ok {
+		return milestone, false
+	}
+
+	return milestone, true
+}
+
+func newProjectMilestoneResponse(milestone sqlc.ProjectMilestone) projectMilestoneResponse {
+	return projectMilestoneResponse{
+		Id:          milestone.ID,
+		ProjectID:   milestone.ProjectID,
+		Title:       milestone.Title,
+		Description: milestone.Description,
+		DueDate:     milestone.DueDate,
+		Completed:   milestone.CompletedAt.Valid,
+		CompletedAt: nullTimeToPointer(milestone.CompletedAt),
+	}
+}
+
+func newProjectMilestoneResponses(milestones []sqlc.ProjectMilestone) []projectMilestoneResponse {
+	responses := make([]projectMilestoneResponse, len(milestones))
+
+	for i, milestone := range milestones {
+		responses[i] = newProjectMilestoneResponse(milestone)
+	}
+
+	return responses
+}
@@ -0,0 +1,631 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CREATE PROJECT POSITION
+type createProjectPositionRequest struct {
+	Role        string   `json:"role" binding:"required"`
+	Description string   `json:"description"`
+	Skills      []string `json:"skills" binding:"dive,required"`
+	Slots       int32    `json:"slots" binding:"required,min=1"`
+}
+
+func (s *Server) createProjectPosition(c *gin.Context) {
+	var uri getProjectRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req createProjectPositionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	_, err := s.query.GetProject(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, uri.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to publish positions for this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, uri.ID); !ok {
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	skills := req.Skills
+	if skills == nil {
+		skills = []string{}
+	}
+
+	position, err := s.query.CreateProjectPosition(c, sqlc.CreateProjectPositionParams{
+		ProjectID:   uri.ID,
+		Role:        req.Role,
+		Description: req.Description,
+		Skills:      skills,
+		Slots:       req.Slots,
+		CreatedBy:   user.ID,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Position created successfully",
+		Data:      position,
+	})
+}
+
+////////////////////////
+
+// GET PROJECT POSITIONS
+func (s *Server) getProjectPositions(c *gin.Context) {
+	var req getProjectRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	_, err := s.query.GetProject(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	positions, err := s.query.GetProjectPositionsByProjectId(c, req.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Positions got successfully",
+		Data:      positions,
+	})
+}
+
+////////////////////////
+
+// GET OPEN POSITIONS
+func (s *Server) getOpenProjectPositions(c *gin.Context) {
+	positions, err := s.query.GetOpenProjectPositions(c)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Open positions got successfully",
+		Data:      positions,
+	})
+}
+
+////////////////////////
+
+// UPDATE PROJECT POSITION
+type projectPositionUriRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type updateProjectPositionRequest struct {
+	Role        *string  `json:"role"`
+	Description *string  `json:"description"`
+	Skills      []string `json:"skills" binding:"omitempty,dive,required"`
+	Slots       *int32   `json:"slots" binding:"omitempty,min=1"`
+	Status      *string  `json:"status" binding:"omitempty,oneof=open closed"`
+}
+
+func (s *Server) updateProjectPosition(c *gin.Context) {
+	var uri projectPositionUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req updateProjectPositionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	position, ok := s.getManageableProjectPosition(c, uri.ID)
+	if !ok {
+		return
+	}
+
+	if req.Role != nil {
+		position.Role = *req.Role
+	}
+
+	if req.Description != nil {
+		position.Description = *req.Description
+	}
+
+	if req.Skills != nil {
+		position.Skills = req.Skills
+	}
+
+	if req.Slots != nil {
+		position.Slots = *req.Slots
+	}
+
+	if req.Status != nil {
+		position.Status = *req.Status
+	}
+
+	if position.Slots < position.FilledSlots {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Slots can not be less than the already filled slots",
+		})
+		return
+	}
+
+	if position.Status == "open" && position.Slots == position.FilledSlots {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "A position without free slots can not be open",
+		})
+		return
+	}
+
+	updated, err := s.query.UpdateProjectPosition(c, sqlc.UpdateProjectPositionParams{
+		ID:          position.ID,
+		Role:        position.Role,
+		Description: position.Description,
+		Skills:      position.Skills,
+		Slots:       position.Slots,
+		Status:      position.Status,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Position updated successfully",
+		Data:      updated,
+	})
+}
+
+////////////////////////
+
+// DELETE PROJECT POSITION
+func (s *Server) deleteProjectPosition(c *gin.Context) {
+	var uri projectPositionUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	position, ok := s.getManageableProjectPosition(c, uri.ID)
+	if !ok {
+		return
+	}
+
+	err := s.query.DeleteProjectPosition(c, position.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Position deleted successfully",
+	})
+}
+
+////////////////////////
+
+// APPLY TO PROJECT POSITION
+type applyToProjectPositionRequest struct {
+	Motivation string `json:"motivation" binding:"required,max=1000"`
+}
+
+func (s *Server) applyToProjectPosition(c *gin.Context) {
+	var uri projectPositionUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req applyToProjectPositionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	position, err := s.query.GetProjectPosition(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Position not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if position.Status != "open" {
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "This position is not open for applications",
+		})
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	_, err = s.query.GetProjectMember(c, sqlc.GetProjectMemberParams{
+		ProjectID: position.ProjectID,
+		UserID:    user.ID,
+	})
+
+	if err == nil {
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are already a member of this project",
+		})
+		return
+	}
+
+	_, err = s.query.GetPendingProjectApplication(c, sqlc.GetPendingProjectApplicationParams{
+		PositionID: position.ID,
+		UserID:     user.ID,
+	})
+
+	if err == nil {
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You already have a pending application for this position",
+		})
+		return
+	}
+
+	application, err := s.query.CreateProjectApplication(c, sqlc.CreateProjectApplicationParams{
+		PositionID: position.ID,
+		UserID:     user.ID,
+		Motivation: req.Motivation,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Application created successfully",
+		Data:      application,
+	})
+}
+
+////////////////////////
+
+// GET PROJECT APPLICATIONS
+func (s *Server) getProjectApplications(c *gin.Context) {
+	var req getProjectRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, req.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see applications of this project",
+		})
+		return
+	}
+
+	applications, err := s.query.GetPendingProjectApplicationsByProjectId(c, req.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Applications got successfully",
+		Data:      applications,
+	})
+}
+
+////////////////////////
+
+// GET CURRENT USER APPLICATIONS
+func (s *Server) getMyProjectApplications(c *gin.Context) {
+	user := c.MustGet("user").(sqlc.User)
+
+	applications, err := s.query.GetProjectApplicationsByUserId(c, user.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Applications got successfully",
+		Data:      applications,
+	})
+}
+
+////////////////////////
+
+// ACCEPT PROJECT APPLICATION
+type reviewProjectApplicationRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (s *Server) acceptProjectApplication(c *gin.Context) {
+	var req reviewProjectApplicationRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	application, ok := s.getReviewableProjectApplication(c, req.ID)
+	if !ok {
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	result, err := s.query.AcceptProjectApplicationTx(c, sqlc.AcceptProjectApplicationTxParams{
+		ApplicationID: application.ID,
+		ReviewerID:    user.ID,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Application was already reviewed",
+			})
+			return
+		}
+		if err == sqlc.ErrPositionFilled {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "This position has no open slots left",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Application accepted successfully",
+		Data:      result,
+	})
+}
+
+////////////////////////
+
+// REJECT PROJECT APPLICATION
+func (s *Server) rejectProjectApplication(c *gin.Context) {
+	var req reviewProjectApplicationRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	application, ok := s.getReviewableProjectApplication(c, req.ID)
+	if !ok {
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	rejected, err := s.query.ReviewProjectApplication(c, sqlc.ReviewProjectApplicationParams{
+		ID:         application.ID,
+		Status:     "rejected",
+		ReviewedBy: sql.NullInt32{Int32: user.ID, Valid: true},
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Application was already reviewed",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Application rejected successfully",
+		Data:      rejected,
+	})
+}
+
+////////////////////////
+
+// UTILS
+// getManageableProjectPosition loads a position and checks that the current user leads its approved project,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) getManageableProjectPosition(c *gin.Context, id int32) (sqlc.ProjectPosition, bool) {
+	position, err := s.query.GetProjectPosition(c, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Position not found",
+			})
+			return position, false
+		}
+		dbErrorResponse(c, err)
+		return position, false
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, position.ProjectID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to manage positions of this project",
+		})
+		return position, false
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, position.ProjectID); !ok {
+		return position, false
+	}
+
+	return position, true
+}
+
+// getReviewableProjectApplication loads an application and checks that the current user leads its approved project,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) getReviewableProjectApplication(c *gin.Context, id int32) (sqlc.GetProjectApplicationRow, bool) {
+	application, err := s.query.GetProjectApplication(c, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Application not found",
+			})
+			return application, false
+		}
+		dbErrorResponse(c, err)
+		return application, false
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, application.ProjectID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to review applications of this project",
+		})
+		return application, false
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, application.ProjectID); !ok {
+		return application, false
+	}
+
+	return application, true
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+	"yildizskylab/src/db/migration"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
+)
+
+// countingDB counts the queries that go through it, like tracedDB it wraps the DBTX the queries are built on
+type countingDB struct {
+	db      sqlc.DBTX
+	queries atomic.Int64
+}
+
+func (c *countingDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.queries.Add(1)
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+func (c *countingDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.queries.Add(1)
+	return c.db.PrepareContext(ctx, query)
+}
+
+func (c *countingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.queries.Add(1)
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+func (c *countingDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	c.queries.Add(1)
+	return c.db.QueryRowContext(ctx, query, args...)
+}
+
+// detailFixture is a team, a project and a user that each have size leads, projects or teams attached
+type detailFixture struct {
+	teamID    int32
+	projectID int32
+	userID    int32
+}
+
+// BenchmarkDetailQueries checks that the detail endpoints run the same number of queries
+// however many leads, projects and teams are attached.
+// It needs a disposable postgres database in TEST_DB_SOURCE, the migrations are applied to it and the seeded rows are left behind
+func BenchmarkDetailQueries(b *testing.B) {
+	dbSource := os.Getenv("TEST_DB_SOURCE")
+	if dbSource == "" {
+		b.Skip("TEST_DB_SOURCE is not set")
+	}
+
+	if err := migration.Up(dbSource); err != nil {
+		b.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", dbSource)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	counter := &countingDB{db: db}
+	server := &Server{query: &sqlc.Store{Queries: sqlc.New(counter)}}
+
+	gin.SetMode(gin.TestMode)
+
+	// an admin passes the permission checks without queries, so only the detail queries are counted
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", sqlc.User{Role: admin})
+		c.Next()
+	})
+	router.GET("/teams/:id", server.getTeam)
+	router.GET("/projects/:id", server.getProject)
+	router.GET("/users/:id", server.getUser)
+
+	endpoints := []struct {
+		name string
+		path func(detailFixture) string
+	}{
+		{"team", func(f detailFixture) string { return fmt.Sprintf("/teams/%d", f.teamID) }},
+		{"project", func(f detailFixture) string { return fmt.Sprintf("/projects/%d", f.projectID) }},
+		{"user", func(f detailFixture) string { return fmt.Sprintf("/users/%d", f.userID) }},
+	}
+
+	sizes := []int{1, 10, 50}
+	// queries per request by endpoint and size, a sub-benchmark runs again for every b.N so the last run wins
+	counts := make(map[string]map[int]int64)
+
+	for _, endpoint := range endpoints {
+		counts[endpoint.name] = make(map[int]int64)
+	}
+
+	for _, size := range sizes {
+		fixture := seedDetailFixture(b, sqlc.New(db), size)
+
+		for _, endpoint := range endpoints {
+			path := endpoint.path(fixture)
+
+			b.Run(fmt.Sprintf("%s/size=%d", endpoint.name, size), func(b *testing.B) {
+				var queries int64
+
+				for i := 0; i < b.N; i++ {
+					counter.queries.Store(0)
+
+					w := httptest.NewRecorder()
+					router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+					if w.Code != http.StatusOK {
+						b.Fatalf("GET %s: status %d: %s", path, w.Code, w.Body.String())
+					}
+
+					queries = counter.queries.Load()
+				}
+
+				b.ReportMetric(float64(queries), "queries/op")
+				counts[endpoint.name][size] = queries
+			})
+		}
+	}
+
+	for name, perSize := range counts {
+		for _, size := range sizes[1:] {
+			if perSize[size] != perSize[sizes[0]] {
+				b.Errorf("%s: %d queries with size %d, %d with size %d", name, perSize[size], size, perSize[sizes[0]], sizes[0])
+			}
+		}
+	}
+}
+
+// seedDetailFixture creates a team and a project with size leads each,
+// size projects under the team, size teams on the project and a user in size teams and projects
+func seedDetailFixture(b *testing.B, q *sqlc.Queries, size int) detailFixture {
+	b.Helper()
+
+	ctx := context.Background()
+	suffix := time.Now().UnixNano()
+
+	must := func(err error) {
+		b.Helper()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	createUser := func(name string) sqlc.User {
+		user, err := q.CreateUser(ctx, sqlc.CreateUserParams{
+			Name:        name,
+			LastName:    "Bench",
+			Email:       fmt.Sprintf("%s-%d@bench.test", name, suffix),
+			Password:    dummyPasswordHash,
+			Role:        "member",
+			DateOfBirth: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		must(err)
+		return user
+	}
+
+	createTeam := func(name string) sqlc.Team {
+		team, err := q.CreateTeam(ctx, sqlc.CreateTeamParams{Name: fmt.Sprintf("%s-%d", name, suffix)})
+		must(err)
+		return team
+	}
+
+	createProject := func(name string) sqlc.Project {
+		project, err := q.CreateProject(ctx, sqlc.CreateProjectParams{
+			Name:           fmt.Sprintf("%s-%d", name, suffix),
+			ApprovalStatus: "approved",
+		})
+		must(err)
+		return project
+	}
+
+	team := createTeam("team")
+	project := createProject("project")
+	member := createUser("member")
+
+	for i := 0; i < size; i++ {
+		leadUser := createUser(fmt.Sprintf("lead%d", i))
+
+		_, err := q.CreateTeamMember(ctx, sqlc.CreateTeamMemberParams{TeamID: team.ID, UserID: leadUser.ID, Role: lead})
+		must(err)
+
+		_, err = q.CreateProjectMember(ctx, sqlc.CreateProjectMemberParams{ProjectID: project.ID, UserID: leadUser.ID, Role: lead})
+		must(err)
+
+		otherTeam := createTeam(fmt.Sprintf("team%d", i))
+		otherProject := createProject(fmt.Sprintf("project%d", i))
+
+		_, err = q.CreateTeamProject(ctx, sqlc.CreateTeamProjectParams{TeamID: team.ID, ProjectID: otherProject.ID})
+		must(err)
+
+		_, err = q.CreateTeamProject(ctx, sqlc.CreateTeamProjectParams{TeamID: otherTeam.ID, ProjectID: project.ID})
+		must(err)
+
+		_, err = q.CreateTeamMember(ctx, sqlc.CreateTeamMemberParams{TeamID: otherTeam.ID, UserID: member.ID, Role: "member"})
+		must(err)
+
+		_, err = q.CreateProjectMember(ctx, sqlc.CreateProjectMemberParams{ProjectID: otherProject.ID, UserID: member.ID, Role: "member"})
+		must(err)
+	}
+
+	return detailFixture{
+		teamID:    team.ID,
+		projectID: project.ID,
+		userID:    member.ID,
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"yildizskylab/src/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dummyPasswordHash is compared against when the email is unknown,
+// so a login takes as long for a missing account as for a wrong password
+const dummyPasswordHash = "$2a$10$f8aho9m1V3tTQIV6B90uKuQ.ZK8GjNQa1KZr94pDPb6yvVI5MqOBu"
+
+// the same message is used for rate limits and locked accounts, so a lock does not reveal that an account exists
+const tooManyAttemptsMessage = "Too many attempts, try again later"
+
+// limitAuthByIP limits the unauthenticated auth endpoints per client address
+func (s *Server) limitAuthByIP(c *gin.Context) {
+	if ok := s.allowAttempt(c, s.ipLimiter, c.ClientIP()); !ok {
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// allowAttempt writes the 429 response itself and reports whether the handler can continue.
+// A failing limiter store lets the request through, an outage of redis should not lock everyone out
+func (s *Server) allowAttempt(c *gin.Context, limiter *ratelimit.Limiter, key string) bool {
+	allowed, retryAfter, err := limiter.Allow(c, key)
+
+	if err != nil {
+		slog.ErrorContext(c, "rate limit check failed", "request_id", requestID(c), "error", err)
+		return true
+	}
+
+	if !allowed {
+		s.metrics.authFailures.WithLabelValues(authRateLimited).Inc()
+		tooManyAttemptsResponse(c, retryAfter)
+		return false
+	}
+
+	return true
+}
+
+// checkAccountLock writes the 429 response itself when the account is locked and reports whether the handler can continue
+func (s *Server) checkAccountLock(c *gin.Context, account string) bool {
+	locked, err := s.lockout.Locked(c, account)
+
+	if err != nil {
+		slog.ErrorContext(c, "lockout check failed", "request_id", requestID(c), "error", err)
+		return true
+	}
+
+	if locked > 0 {
+		s.metrics.authFailures.WithLabelValues(authLocked).Inc()
+		tooManyAttemptsResponse(c, locked)
+		return false
+	}
+
+	return true
+}
+
+// loginFailed counts a failed login towards the lockout of the account
+func (s *Server) loginFailed(c *gin.Context, account string) {
+	s.metrics.authFailures.WithLabelValues(authInvalidCredentials).Inc()
+
+	locked, err := s.lockout.Fail(c, account)
+
+	if err != nil {
+		slog.ErrorContext(c, "recording failed login failed", "request_id", requestID(c), "error", err)
+		return
+	}
+
+	if locked > 0 {
+		slog.WarnContext(c, "account locked", "request_id", requestID(c), "lock", locked.String())
+	}
+}
+
+func tooManyAttemptsResponse(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+
+	c.JSON(http.StatusTooManyRequests, Response{
+		IsSuccess: false,
+		RequestID: requestID(c),
+		Message:   tooManyAttemptsMessage,
+	})
+}
+
+// accountKey makes the limits of an email independent of its casing
+func accountKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -2,11 +2,18 @@ package api
 
 import (
 	"context"
-	"time"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
 	"yildizskylab/src/db/sqlc"
+	"yildizskylab/src/ratelimit"
+	"yildizskylab/src/util"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 const (
@@ -15,35 +22,69 @@ const (
 )
 
 type Server struct {
-	query  *sqlc.Queries
+	query  *sqlc.Store
 	router *gin.Engine
-	secret string
+	config util.Config
+	// metrics is also served on MetricsAddress when that is set
+	metrics *metrics
+	// draining is set once shutdown starts so /readyz takes the server out of rotation
+	draining atomic.Bool
+	// the auth endpoints are limited per client address and per account, failed logins lock the account
+	ipLimiter      *ratelimit.Limiter
+	accountLimiter *ratelimit.Limiter
+	signupLimiter  *ratelimit.Limiter
+	lockout        *ratelimit.Lockout
 }
 
-func NewServer(query *sqlc.Queries, secret string) *Server {
+func NewServer(query *sqlc.Store, limits ratelimit.Store, config util.Config) (*Server, error) {
 
 	server := &Server{
-		query:  query,
-		secret: secret,
+		query:          query,
+		config:         config,
+		metrics:        newMetrics(query),
+		ipLimiter:      ratelimit.NewLimiter(limits, "auth:ip:", config.AuthIPLimit, config.AuthLimitWindow),
+		accountLimiter: ratelimit.NewLimiter(limits, "auth:account:", config.AuthAccountLimit, config.AuthLimitWindow),
+		signupLimiter:  ratelimit.NewLimiter(limits, "signup:account:", config.AuthAccountLimit, config.AuthLimitWindow),
+		lockout:        ratelimit.NewLockout(limits, config.LockoutThreshold, config.LockoutDuration, config.LockoutMaxDuration, config.LockoutResetAfter),
 	}
 
-	router := gin.Default()
+	router := gin.New()
 
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
+	// c.ClientIP only reads X-Forwarded-For from these proxies, otherwise a client could pick the address it is limited by
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		return nil, err
+	}
+
+	// handlers pass c as the context of their queries, the fallback makes it carry the request span
+	router.ContextWithFallback = true
 
-	router.GET("/deneme", server.RequireAuth, server.RequireRole([]string{"mod"}, server.getAllTeams))
+	// the request id comes first so the access log and the recovery can both use it,
+	// the span is started before the access log so the log line carries the trace id
+	router.Use(requestIDMiddleware)
+	router.Use(otelgin.Middleware(config.ServiceName))
+	router.Use(requestLogger)
+	router.Use(server.metrics.instrument)
+	router.Use(gin.CustomRecoveryWithWriter(io.Discard, recoverPanic))
+	router.Use(corsMiddleware(config))
+	router.Use(limitBody(config.MaxBodySize))
+
+	//health
+	router.GET("/healthz", server.healthz)
+	router.GET("/readyz", server.readyz)
+	router.GET("/version", server.version)
+
+	//metrics
+	if config.MetricsAddress == "" && config.MetricsToken != "" {
+		router.GET("/metrics", requireMetricsToken(config.MetricsToken), gin.WrapH(server.metrics.handler()))
+	}
 
 	//team
 	router.POST("/teams", server.RequireAuth, server.RequireRole([]string{admin}, server.createTeam))
 	router.GET("/teams/:id", server.RequireAuth, server.RequireRole([]string{admin, lead}, server.getTeam))
 	router.GET("/teams", server.RequireAuth, server.getAllTeams)
+	router.GET("/teams/:id/members", server.RequireAuth, server.getTeamMembers)
+	router.GET("/teams/tree", server.RequireAuth, server.getTeamTree)
+	router.PUT("/teams/:id/parent", server.RequireAuth, server.RequireRole([]string{admin}, server.updateTeamParent))
 	router.PUT("/teams/:id", server.RequireAuth, server.RequireRole([]string{admin, lead}, server.updateTeam))
 	router.DELETE("/teams/:id", server.RequireAuth, server.RequireRole([]string{admin}, server.deleteTeam))
 	router.POST("/teams/project", server.RequireAuth, server.RequireRole([]string{admin, lead}, server.addTeamProject))
@@ -51,10 +92,20 @@ func NewServer(query *sqlc.Queries, secret string) *Server {
 	router.POST("/teams/member", server.RequireAuth, server.RequireRole([]string{admin, lead}, server.addTeamMember))
 	router.POST("/teams/lead", server.RequireAuth, server.RequireRole([]string{admin}, server.addTeamLead))
 	router.DELETE("/teams/member", server.RequireAuth, server.RequireRole([]string{admin, lead}, server.removeTeamMember))
+	router.PUT("/teams/member/role", server.RequireAuth, server.changeTeamMemberRole)
+	router.POST("/teams/lead/transfer", server.RequireAuth, server.transferTeamLeadership)
+	router.POST("/teams/:id/join-requests", server.RequireAuth, server.createTeamJoinRequest)
+	router.GET("/teams/:id/join-requests", server.RequireAuth, server.getTeamJoinRequests)
+	router.POST("/teams/join-requests/:id/approve", server.RequireAuth, server.approveTeamJoinRequest)
+	router.POST("/teams/join-requests/:id/reject", server.RequireAuth, server.rejectTeamJoinRequest)
+	router.POST("/teams/:id/invitations", server.RequireAuth, server.createTeamInvitation)
+	router.GET("/teams/:id/invitations", server.RequireAuth, server.getTeamInvitations)
+	router.DELETE("/teams/invitations/:id", server.RequireAuth, server.revokeTeamInvitation)
+	router.POST("/teams/invitations/accept", server.RequireAuth, server.acceptTeamInvitation)
 
 	//user
-	router.POST("/users/signup", server.signup)
-	router.POST("/users/login", server.login)
+	router.POST("/users/signup", server.limitAuthByIP, server.signup)
+	router.POST("/users/login", server.limitAuthByIP, server.login)
 	router.GET("/users/:id", server.RequireAuth, server.getUser)
 	router.GET("/users", server.RequireAuth, server.getAllUsers)
 	router.PUT("/users/:id", server.RequireAuth, server.updateUser)
@@ -65,11 +116,42 @@ func NewServer(query *sqlc.Queries, secret string) *Server {
 	router.POST("/projects", server.RequireAuth, server.createProject)
 	router.GET("/projects/:id", server.RequireAuth, server.getProject)
 	router.GET("/projects", server.RequireAuth, server.getAllProjects)
+	router.GET("/projects/pending", server.RequireAuth, server.RequireRole([]string{admin}, server.getPendingProjects))
+	router.POST("/projects/:id/approve", server.RequireAuth, server.RequireRole([]string{admin}, server.approveProject))
+	router.POST("/projects/:id/reject", server.RequireAuth, server.RequireRole([]string{admin}, server.rejectProject))
+	router.PUT("/projects/:id/status", server.RequireAuth, server.updateProjectStatus)
+	router.POST("/projects/:id/milestones", server.RequireAuth, server.createProjectMilestone)
+	router.GET("/projects/:id/milestones", server.RequireAuth, server.getProjectMilestones)
+	router.PUT("/projects/milestones/:id", server.RequireAuth, server.updateProjectMilestone)
+	router.DELETE("/projects/milestones/:id", server.RequireAuth, server.deleteProjectMilestone)
 	router.PUT("/projects/:id", server.RequireAuth, server.updateProject)
 	router.DELETE("/projects/:id", server.RequireAuth, server.deleteProject)
 	router.POST("/projects/member", server.RequireAuth, server.addProjectMember)
 	router.POST("/projects/lead", server.RequireAuth, server.addProjectLead)
 	router.DELETE("/projects/member", server.RequireAuth, server.removeProjectMember)
+	router.PUT("/projects/member/role", server.RequireAuth, server.changeProjectMemberRole)
+	router.POST("/projects/lead/transfer", server.RequireAuth, server.transferProjectLeadership)
+	router.GET("/projects/positions", server.RequireAuth, server.getOpenProjectPositions)
+	router.POST("/projects/:id/positions", server.RequireAuth, server.createProjectPosition)
+	router.GET("/projects/:id/positions", server.RequireAuth, server.getProjectPositions)
+	router.PUT("/projects/positions/:id", server.RequireAuth, server.updateProjectPosition)
+	router.DELETE("/projects/positions/:id", server.RequireAuth, server.deleteProjectPosition)
+	router.POST("/projects/positions/:id/applications", server.RequireAuth, server.applyToProjectPosition)
+	router.GET("/projects/:id/applications", server.RequireAuth, server.getProjectApplications)
+	router.GET("/projects/applications/me", server.RequireAuth, server.getMyProjectApplications)
+	router.POST("/projects/applications/:id/accept", server.RequireAuth, server.acceptProjectApplication)
+	router.POST("/projects/applications/:id/reject", server.RequireAuth, server.rejectProjectApplication)
+	router.PUT("/projects/:id/showcase", server.RequireAuth, server.updateProjectShowcase)
+	router.POST("/projects/:id/gallery", server.RequireAuth, server.addProjectGalleryImage)
+	router.DELETE("/projects/:id/gallery/:image_id", server.RequireAuth, server.removeProjectGalleryImage)
+
+	//task
+	router.POST("/projects/:id/tasks", server.RequireAuth, server.createTask)
+	router.GET("/projects/:id/tasks", server.RequireAuth, server.getTaskBoard)
+	router.GET("/tasks/me", server.RequireAuth, server.getMyTasks)
+	router.PUT("/tasks/:id", server.RequireAuth, server.updateTask)
+	router.POST("/tasks/:id/move", server.RequireAuth, server.moveTask)
+	router.DELETE("/tasks/:id", server.RequireAuth, server.deleteTask)
 
 	//image
 	router.POST("/images", server.RequireAuth, server.createImage)
@@ -80,26 +162,195 @@ func NewServer(query *sqlc.Queries, secret string) *Server {
 	router.GET("/news", server.getAllNews)
 	router.GET("/news/:id", server.getNews)
 
+	//trash
+	router.GET("/trash", server.RequireAuth, server.RequireRole([]string{admin}, server.getTrash))
+	router.POST("/trash/teams/:id/restore", server.RequireAuth, server.RequireRole([]string{admin}, server.restoreTeam))
+	router.POST("/trash/projects/:id/restore", server.RequireAuth, server.RequireRole([]string{admin}, server.restoreProject))
+	router.POST("/trash/users/:id/restore", server.RequireAuth, server.RequireRole([]string{admin}, server.restoreUser))
+	router.POST("/trash/team-members/:id/restore", server.RequireAuth, server.RequireRole([]string{admin}, server.restoreTeamMember))
+	router.POST("/trash/project-members/:id/restore", server.RequireAuth, server.RequireRole([]string{admin}, server.restoreProjectMember))
+
+	//public
+	router.GET("/public/projects", server.getPublicProjects)
+	router.GET("/public/projects/:id", server.getPublicProject)
+	router.GET("/public/teams", server.getPublicTeams)
+	router.GET("/public/teams/:id", server.getPublicTeam)
+	router.GET("/public/org-chart", server.getOrgChart)
+
 	server.router = router
 
-	return server
+	return server, nil
 }
 
-func (s *Server) Start(address string) error {
-
-	s.query.CreateUser(context.Background(), sqlc.CreateUserParams{
-		Name:            "admin",
-		LastName:        "admin",
-		Email:           "admin@admin.com",
-		Password:        "$2a$10$3QYYykR1IWPX.KG9ne2mN..A/jZjynJd4qK.o0lRDxR/KAxBQTCXi",
-		TelephoneNumber: "123123123",
-		Role:            "admin",
-		University:      "ytu",
-		Department:      "mtm",
-		DateOfBirth:     time.Now(),
-	})
+// Start serves until ctx is done, then it stops accepting connections and
+// waits up to ShutdownTimeout for the requests in flight before returning
+func (s *Server) Start(ctx context.Context) error {
+	servers := []*http.Server{s.newHTTPServer(s.config.ServerAddress, s.router)}
+
+	if s.config.MetricsAddress != "" {
+		handler := gin.New()
+		handler.Use(gin.Recovery())
+
+		metricsHandlers := []gin.HandlerFunc{gin.WrapH(s.metrics.handler())}
+
+		if s.config.MetricsToken != "" {
+			metricsHandlers = append([]gin.HandlerFunc{requireMetricsToken(s.config.MetricsToken)}, metricsHandlers...)
+		}
+
+		handler.GET("/metrics", metricsHandlers...)
+
+		servers = append(servers, s.newHTTPServer(s.config.MetricsAddress, handler))
+	}
+
+	go s.runTrashPurge(ctx)
+
+	serveErr := make(chan error, len(servers))
+
+	for _, server := range servers {
+		go func() {
+			serveErr <- server.ListenAndServe()
+		}()
+	}
+
+	var err error
+
+	select {
+	case err = <-serveErr:
+		// one listener failing stops the others too
+	case <-ctx.Done():
+	}
+
+	s.draining.Store(true)
+
+	slog.Info("shutting down, waiting for requests in flight", "timeout", s.config.ShutdownTimeout.String())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
 
-	return s.router.Run(address)
+	for _, server := range servers {
+		err = errors.Join(err, server.Shutdown(shutdownCtx))
+	}
+
+	return err
+}
+
+func (s *Server) newHTTPServer(address string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
+		ReadTimeout:       s.config.ReadTimeout,
+		WriteTimeout:      s.config.WriteTimeout,
+		IdleTimeout:       s.config.IdleTimeout,
+		MaxHeaderBytes:    s.config.MaxHeaderBytes,
+	}
+}
+
+// limitBody rejects bodies larger than maxBytes, the reader is limited too because the length is not always known up front
+func limitBody(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Request body is too large",
+			})
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
+
+// leadershipErrorResponse maps the errors of the role changing transactions to responses
+func (s *Server) leadershipErrorResponse(c *gin.Context, err error) {
+	switch err {
+	case sqlc.ErrLastLead:
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "At least one lead must remain, transfer the leadership first",
+		})
+	case sqlc.ErrNotLead:
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Leadership can only be transferred from a current lead",
+		})
+	case sqlc.ErrNotMember:
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "User is not a member",
+		})
+	default:
+		dbErrorResponse(c, err)
+	}
+}
+
+// Postgres error codes of the constraint violations that are caused by the request
+const (
+	uniqueViolation     = pq.ErrorCode("23505")
+	foreignKeyViolation = pq.ErrorCode("23503")
+	checkViolation      = pq.ErrorCode("23514")
+	notNullViolation    = pq.ErrorCode("23502")
+)
+
+// uniqueViolationMessages explains the unique indexes a request can run into
+var uniqueViolationMessages = map[string]string{
+	"users_email_active_idx":                      "Email is already in use",
+	"team_users_team_id_user_id_active_idx":       "User is already a member of this team",
+	"project_users_project_id_user_id_active_idx": "User is already a member of this project",
+}
+
+// dbErrorResponse maps constraint violations to 409 and 422 responses,
+// any other error is logged and answered with a generic 500 so no internals leak to the client
+func dbErrorResponse(c *gin.Context, err error) {
+	var pqErr *pq.Error
+
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case uniqueViolation:
+			message, ok := uniqueViolationMessages[pqErr.Constraint]
+			if !ok {
+				message = "Record already exists"
+			}
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   message,
+			})
+			return
+		case foreignKeyViolation:
+			c.JSON(http.StatusUnprocessableEntity, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Referenced record does not exist",
+			})
+			return
+		case checkViolation, notNullViolation:
+			c.JSON(http.StatusUnprocessableEntity, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Invalid value for " + pqErr.Column,
+			})
+			return
+		}
+	}
+
+	slog.ErrorContext(c, "request failed",
+		"request_id", requestID(c),
+		"method", c.Request.Method,
+		"route", c.FullPath(),
+		"error", err,
+	)
+
+	c.JSON(http.StatusInternalServerError, Response{
+		IsSuccess: false,
+		RequestID: requestID(c),
+		Message:   "Internal server error",
+	})
 }
 
 func errorResponse(err error) gin.H {
@@ -107,7 +358,10 @@ func errorResponse(err error) gin.H {
 }
 
 type Response struct {
-	IsSuccess bool        `json:"isSuccess"`
-	Message   string      `json:"message"`
-	Data      interface{} `json:"data"`
+	IsSuccess bool `json:"isSuccess"`
+	// RequestID is only set on errors so a reported failure can be found in the logs
+	RequestID  string      `json:"request_id,omitempty"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	Pagination *pagination `json:"pagination,omitempty"`
 }
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+func TestDBErrorResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{
+			name:    "known unique index",
+			err:     &pq.Error{Code: uniqueViolation, Constraint: "users_email_active_idx"},
+			status:  http.StatusConflict,
+			message: "Email is already in use",
+		},
+		{
+			name:    "unknown unique index",
+			err:     &pq.Error{Code: uniqueViolation, Constraint: "some_other_idx"},
+			status:  http.StatusConflict,
+			message: "Record already exists",
+		},
+		{
+			name:    "wrapped unique violation",
+			err:     fmt.Errorf("create member: %w", &pq.Error{Code: uniqueViolation, Constraint: "team_users_team_id_user_id_active_idx"}),
+			status:  http.StatusConflict,
+			message: "User is already a member of this team",
+		},
+		{
+			name:    "foreign key violation",
+			err:     &pq.Error{Code: foreignKeyViolation},
+			status:  http.StatusUnprocessableEntity,
+			message: "Referenced record does not exist",
+		},
+		{
+			name:    "check violation",
+			err:     &pq.Error{Code: checkViolation, Column: "status"},
+			status:  http.StatusUnprocessableEntity,
+			message: "Invalid value for status",
+		},
+		{
+			name:    "not null violation",
+			err:     &pq.Error{Code: notNullViolation, Column: "name"},
+			status:  http.StatusUnprocessableEntity,
+			message: "Invalid value for name",
+		},
+		{
+			name:    "other postgres error",
+			err:     &pq.Error{Code: "40001", Message: "could not serialize access"},
+			status:  http.StatusInternalServerError,
+			message: "Internal server error",
+		},
+		{
+			name:    "no rows",
+			err:     sql.ErrNoRows,
+			status:  http.StatusInternalServerError,
+			message: "Internal server error",
+		},
+		{
+			name:    "other error",
+			err:     errors.New("connection refused"),
+			status:  http.StatusInternalServerError,
+			message: "Internal server error",
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	// the 500s are logged, the test output does not need them
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer slog.SetDefault(logger)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Set(requestIDKey, "test-request")
+
+			dbErrorResponse(c, tt.err)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+
+			var res Response
+
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+
+			if res.IsSuccess || res.Message != tt.message || res.RequestID != "test-request" {
+				t.Errorf("response = %+v, want message %q with the request id", res, tt.message)
+			}
+		})
+	}
+}
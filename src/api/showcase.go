@@ -0,0 +1,426 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+type publicImageResponse struct {
+	Id   int32  `json:"id"`
+	URL  string `json:"url"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type publicMemberResponse struct {
+	Name     string `json:"name"`
+	LastName string `json:"last_name"`
+	Role     string `json:"role"`
+}
+
+// publicProjectResponse only carries the fields that may be shown to visitors of the club website
+type publicProjectResponse struct {
+	Id            int32                  `json:"id"`
+	Name          string                 `json:"name"`
+	Summary       string                 `json:"summary"`
+	Status        string                 `json:"status"`
+	TechStack     []string               `json:"tech_stack"`
+	RepositoryURL string                 `json:"repository_url"`
+	DemoURL       string                 `json:"demo_url"`
+	CoverImageURL string                 `json:"cover_image_url"`
+	Gallery       []publicImageResponse  `json:"gallery,omitempty"`
+	Members       []publicMemberResponse `json:"members,omitempty"`
+}
+
+// GET PUBLIC PROJECTS
+func (s *Server) getPublicProjects(c *gin.Context) {
+	var req cursorRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	rows, page, ok := listAfterCursor(c, req, pageQueries[sqlc.GetPublishedProjectsRow]{
+		count: func() (int64, error) {
+			return s.query.CountPublishedProjects(c)
+		},
+		afterCursor: func(cursor, limit int32) ([]sqlc.GetPublishedProjectsRow, error) {
+			return s.query.GetPublishedProjects(c, sqlc.GetPublishedProjectsParams{
+				ID:    cursor,
+				Limit: limit,
+			})
+		},
+		id: func(row sqlc.GetPublishedProjectsRow) int32 {
+			return row.ID
+		},
+	})
+
+	if !ok {
+		return
+	}
+
+	projects := make([]publicProjectResponse, len(rows))
+
+	for i, row := range rows {
+		projects[i] = s.newPublicProjectResponse(sqlc.GetPublishedProjectRow(row))
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess:  true,
+		Message:    "Projects got successfully",
+		Data:       projects,
+		Pagination: page,
+	})
+}
+
+////////////////////////
+
+// GET PUBLIC PROJECT
+func (s *Server) getPublicProject(c *gin.Context) {
+	var req getProjectRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	row, err := s.query.GetPublishedProject(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	images, err := s.query.GetProjectImagesByProjectId(c, row.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	members, err := s.query.GetPublicProjectMembers(c, row.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	project := s.newPublicProjectResponse(row)
+
+	project.Gallery = make([]publicImageResponse, len(images))
+
+	for i, image := range images {
+		project.Gallery[i] = publicImageResponse{
+			Id:   image.ID,
+			URL:  s.imageURL(image.Url),
+			Type: image.Type,
+			Name: image.Name,
+		}
+	}
+
+	project.Members = make([]publicMemberResponse, len(members))
+
+	for i, member := range members {
+		project.Members[i] = publicMemberResponse{
+			Name:     member.Name,
+			LastName: member.LastName,
+			Role:     member.Role,
+		}
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Project got successfully",
+		Data:      project,
+	})
+}
+
+////////////////////////
+
+// UPDATE PROJECT SHOWCASE
+type updateProjectShowcaseRequest struct {
+	Summary       *string  `json:"summary" binding:"omitempty,max=1000"`
+	TechStack     []string `json:"tech_stack" binding:"omitempty,dive,required"`
+	RepositoryURL *string  `json:"repository_url" binding:"omitempty,url"`
+	DemoURL       *string  `json:"demo_url" binding:"omitempty,url"`
+	// CoverImageID set to 0 removes the cover image
+	CoverImageID *int32 `json:"cover_image_id" binding:"omitempty,min=0"`
+	IsPublished  *bool  `json:"is_published"`
+}
+
+func (s *Server) updateProjectShowcase(c *gin.Context) {
+	var uri getProjectRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req updateProjectShowcaseRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, uri.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to edit the showcase of this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, uri.ID); !ok {
+		return
+	}
+
+	project, err := s.query.GetProject(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if req.Summary != nil {
+		project.Summary = *req.Summary
+	}
+
+	if req.TechStack != nil {
+		project.TechStack = req.TechStack
+	}
+
+	if req.RepositoryURL != nil {
+		project.RepositoryUrl = *req.RepositoryURL
+	}
+
+	if req.DemoURL != nil {
+		project.DemoUrl = *req.DemoURL
+	}
+
+	if req.CoverImageID != nil {
+		if *req.CoverImageID != 0 {
+			if ok := s.checkImageExists(c, *req.CoverImageID); !ok {
+				return
+			}
+		}
+
+		project.CoverImageID = sql.NullInt32{Int32: *req.CoverImageID, Valid: *req.CoverImageID != 0}
+	}
+
+	if req.IsPublished != nil {
+		project.IsPublished = *req.IsPublished
+	}
+
+	updated, err := s.query.UpdateProjectShowcase(c, sqlc.UpdateProjectShowcaseParams{
+		ID:            project.ID,
+		Summary:       project.Summary,
+		TechStack:     project.TechStack,
+		RepositoryUrl: project.RepositoryUrl,
+		DemoUrl:       project.DemoUrl,
+		CoverImageID:  project.CoverImageID,
+		IsPublished:   project.IsPublished,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Project showcase updated successfully",
+		Data:      updated,
+	})
+}
+
+////////////////////////
+
+// ADD PROJECT GALLERY IMAGE
+type addProjectGalleryImageRequest struct {
+	ImageID int32 `json:"image_id" binding:"required,min=1"`
+}
+
+func (s *Server) addProjectGalleryImage(c *gin.Context) {
+	var uri getProjectRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req addProjectGalleryImageRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, uri.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to edit the gallery of this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, uri.ID); !ok {
+		return
+	}
+
+	if ok := s.checkImageExists(c, req.ImageID); !ok {
+		return
+	}
+
+	projectImage, err := s.query.CreateProjectImage(c, sqlc.CreateProjectImageParams{
+		ProjectID: uri.ID,
+		ImageID:   req.ImageID,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Image added to gallery successfully",
+		Data:      projectImage,
+	})
+}
+
+////////////////////////
+
+// REMOVE PROJECT GALLERY IMAGE
+type removeProjectGalleryImageRequest struct {
+	ID      int32 `uri:"id" binding:"required,min=1"`
+	ImageID int32 `uri:"image_id" binding:"required,min=1"`
+}
+
+func (s *Server) removeProjectGalleryImage(c *gin.Context) {
+	var req removeProjectGalleryImageRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectLead(c, req.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to edit the gallery of this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, req.ID); !ok {
+		return
+	}
+
+	err := s.query.DeleteProjectImage(c, sqlc.DeleteProjectImageParams{
+		ProjectID: req.ID,
+		ImageID:   req.ImageID,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Image removed from gallery successfully",
+	})
+}
+
+////////////////////////
+
+// UTILS
+func (s *Server) newPublicProjectResponse(row sqlc.GetPublishedProjectRow) publicProjectResponse {
+	project := publicProjectResponse{
+		Id:            row.ID,
+		Name:          row.Name,
+		Summary:       row.Summary,
+		Status:        row.Status,
+		TechStack:     row.TechStack,
+		RepositoryURL: row.RepositoryUrl,
+		DemoURL:       row.DemoUrl,
+	}
+
+	if row.CoverImageUrl.Valid {
+		project.CoverImageURL = s.imageURL(row.CoverImageUrl.String)
+	}
+
+	return project
+}
+
+// checkImageExists writes the error response itself and reports whether the handler can continue
+func (s *Server) checkImageExists(c *gin.Context, imageID int32) bool {
+	_, err := s.query.GetImageInfo(c, imageID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusUnprocessableEntity, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Image not found",
+			})
+			return false
+		}
+		dbErrorResponse(c, err)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,564 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	taskStatusTodo       = "todo"
+	taskStatusInProgress = "in_progress"
+	taskStatusReview     = "review"
+	taskStatusDone       = "done"
+)
+
+type taskAssigneeResponse struct {
+	Id       int32  `json:"id"`
+	Name     string `json:"name"`
+	LastName string `json:"last_name"`
+}
+
+type taskResponse struct {
+	Id          int32                 `json:"id"`
+	ProjectID   int32                 `json:"project_id"`
+	ProjectName string                `json:"project_name,omitempty"`
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	AssigneeID  *int32                `json:"assignee_id"`
+	Assignee    *taskAssigneeResponse `json:"assignee,omitempty"`
+	Status      string                `json:"status"`
+	Priority    string                `json:"priority"`
+	DueDate     *time.Time            `json:"due_date"`
+	Labels      []string              `json:"labels"`
+	Position    int32                 `json:"position"`
+	CreatedBy   int32                 `json:"created_by"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+type taskBoardResponse struct {
+	Todo       []taskResponse `json:"todo"`
+	InProgress []taskResponse `json:"in_progress"`
+	Review     []taskResponse `json:"review"`
+	Done       []taskResponse `json:"done"`
+}
+
+// CREATE TASK
+type createTaskRequest struct {
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	AssigneeID  int32      `json:"assignee_id" binding:"omitempty,min=1"`
+	Status      string     `json:"status" binding:"omitempty,oneof=todo in_progress review done"`
+	Priority    string     `json:"priority" binding:"omitempty,oneof=low medium high urgent"`
+	DueDate     *time.Time `json:"due_date"`
+	Labels      []string   `json:"labels" binding:"dive,required"`
+}
+
+func (s *Server) createTask(c *gin.Context) {
+	var uri getProjectRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req createTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	_, err := s.query.GetProject(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Project not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectMember(c, uri.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to add tasks to this project",
+		})
+		return
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, uri.ID); !ok {
+		return
+	}
+
+	if req.AssigneeID != 0 {
+		if ok := s.checkTaskAssignee(c, uri.ID, req.AssigneeID); !ok {
+			return
+		}
+	}
+
+	if req.Status == "" {
+		req.Status = taskStatusTodo
+	}
+
+	if req.Priority == "" {
+		req.Priority = "medium"
+	}
+
+	if req.Labels == nil {
+		req.Labels = []string{}
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	task, err := s.query.CreateProjectTaskTx(c, sqlc.CreateProjectTaskParams{
+		ProjectID:   uri.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		AssigneeID:  sql.NullInt32{Int32: req.AssigneeID, Valid: req.AssigneeID != 0},
+		Status:      req.Status,
+		Priority:    req.Priority,
+		DueDate:     timePointerToNullTime(req.DueDate),
+		Labels:      req.Labels,
+		CreatedBy:   user.ID,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Task created successfully",
+		Data:      newTaskResponse(task),
+	})
+}
+
+////////////////////////
+
+// GET TASK BOARD
+func (s *Server) getTaskBoard(c *gin.Context) {
+	var req getProjectRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsProjectMember(c, req.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see tasks of this project",
+		})
+		return
+	}
+
+	rows, err := s.query.GetProjectTasksByProjectId(c, req.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	board := taskBoardResponse{
+		Todo:       []taskResponse{},
+		InProgress: []taskResponse{},
+		Review:     []taskResponse{},
+		Done:       []taskResponse{},
+	}
+
+	for _, row := range rows {
+		task := newTaskResponse(sqlc.ProjectTask{
+			ID:          row.ID,
+			ProjectID:   row.ProjectID,
+			Title:       row.Title,
+			Description: row.Description,
+			AssigneeID:  row.AssigneeID,
+			Status:      row.Status,
+			Priority:    row.Priority,
+			DueDate:     row.DueDate,
+			Labels:      row.Labels,
+			Position:    row.Position,
+			CreatedBy:   row.CreatedBy,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+
+		if row.AssigneeID.Valid {
+			task.Assignee = &taskAssigneeResponse{
+				Id:       row.AssigneeID.Int32,
+				Name:     row.AssigneeName.String,
+				LastName: row.AssigneeLastName.String,
+			}
+		}
+
+		switch row.Status {
+		case taskStatusTodo:
+			board.Todo = append(board.Todo, task)
+		case taskStatusInProgress:
+			board.InProgress = append(board.InProgress, task)
+		case taskStatusReview:
+			board.Review = append(board.Review, task)
+		case taskStatusDone:
+			board.Done = append(board.Done, task)
+		}
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Tasks got successfully",
+		Data:      board,
+	})
+}
+
+////////////////////////
+
+// GET MY TASKS
+func (s *Server) getMyTasks(c *gin.Context) {
+	user := c.MustGet("user").(sqlc.User)
+
+	rows, err := s.query.GetProjectTasksByAssigneeId(c, sql.NullInt32{Int32: user.ID, Valid: true})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	tasks := make([]taskResponse, len(rows))
+
+	for i, row := range rows {
+		tasks[i] = newTaskResponse(sqlc.ProjectTask{
+			ID:          row.ID,
+			ProjectID:   row.ProjectID,
+			Title:       row.Title,
+			Description: row.Description,
+			AssigneeID:  row.AssigneeID,
+			Status:      row.Status,
+			Priority:    row.Priority,
+			DueDate:     row.DueDate,
+			Labels:      row.Labels,
+			Position:    row.Position,
+			CreatedBy:   row.CreatedBy,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		})
+		tasks[i].ProjectName = row.ProjectName
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Tasks got successfully",
+		Data:      tasks,
+	})
+}
+
+////////////////////////
+
+// UPDATE TASK
+type taskUriRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type updateTaskRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	// AssigneeID set to 0 unassigns the task
+	AssigneeID *int32     `json:"assignee_id" binding:"omitempty,min=0"`
+	Priority   *string    `json:"priority" binding:"omitempty,oneof=low medium high urgent"`
+	DueDate    *time.Time `json:"due_date"`
+	Labels     []string   `json:"labels" binding:"omitempty,dive,required"`
+}
+
+func (s *Server) updateTask(c *gin.Context) {
+	var uri taskUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req updateTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	task, ok := s.getAccessibleTask(c, uri.ID)
+	if !ok {
+		return
+	}
+
+	if req.Title != nil {
+		task.Title = *req.Title
+	}
+
+	if req.Description != nil {
+		task.Description = *req.Description
+	}
+
+	if req.AssigneeID != nil {
+		if *req.AssigneeID != 0 {
+			if ok := s.checkTaskAssignee(c, task.ProjectID, *req.AssigneeID); !ok {
+				return
+			}
+		}
+
+		task.AssigneeID = sql.NullInt32{Int32: *req.AssigneeID, Valid: *req.AssigneeID != 0}
+	}
+
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+
+	if req.DueDate != nil {
+		task.DueDate = timePointerToNullTime(req.DueDate)
+	}
+
+	if req.Labels != nil {
+		task.Labels = req.Labels
+	}
+
+	updated, err := s.query.UpdateProjectTask(c, sqlc.UpdateProjectTaskParams{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		AssigneeID:  task.AssigneeID,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		Labels:      task.Labels,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Task updated successfully",
+		Data:      newTaskResponse(updated),
+	})
+}
+
+////////////////////////
+
+// MOVE TASK
+type moveTaskRequest struct {
+	Status   string `json:"status" binding:"required,oneof=todo in_progress review done"`
+	Position *int32 `json:"position" binding:"required,min=0"`
+}
+
+func (s *Server) moveTask(c *gin.Context) {
+	var uri taskUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req moveTaskRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	task, ok := s.getAccessibleTask(c, uri.ID)
+	if !ok {
+		return
+	}
+
+	moved, err := s.query.MoveProjectTaskTx(c, sqlc.MoveProjectTaskTxParams{
+		TaskID:   task.ID,
+		Status:   req.Status,
+		Position: *req.Position,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Task moved successfully",
+		Data:      newTaskResponse(moved),
+	})
+}
+
+////////////////////////
+
+// DELETE TASK
+func (s *Server) deleteTask(c *gin.Context) {
+	var uri taskUriRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	task, ok := s.getAccessibleTask(c, uri.ID)
+	if !ok {
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	if task.CreatedBy != user.ID && !s.checkIfUserIsProjectLead(c, task.ProjectID) {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Only the creator of the task or a project lead can delete it",
+		})
+		return
+	}
+
+	err := s.query.DeleteProjectTaskTx(c, task.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Task deleted successfully",
+	})
+}
+
+////////////////////////
+
+// UTILS
+// getAccessibleTask loads a task and checks that the current user is a member of its approved project,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) getAccessibleTask(c *gin.Context, id int32) (sqlc.ProjectTask, bool) {
+	task, err := s.query.GetProjectTask(c, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Task not found",
+			})
+			return task, false
+		}
+		dbErrorResponse(c, err)
+		return task, false
+	}
+
+	if ok := s.checkIfUserIsProjectMember(c, task.ProjectID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to manage tasks of this project",
+		})
+		return task, false
+	}
+
+	if ok := s.checkIfProjectIsApproved(c, task.ProjectID); !ok {
+		return task, false
+	}
+
+	return task, true
+}
+
+// checkTaskAssignee checks that the assignee is a member of the project,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) checkTaskAssignee(c *gin.Context, projectID int32, assigneeID int32) bool {
+	_, err := s.query.GetProjectMember(c, sqlc.GetProjectMemberParams{
+		ProjectID: projectID,
+		UserID:    assigneeID,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusUnprocessableEntity, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Tasks can only be assigned to members of the project",
+			})
+			return false
+		}
+		dbErrorResponse(c, err)
+		return false
+	}
+
+	return true
+}
+
+func newTaskResponse(task sqlc.ProjectTask) taskResponse {
+	var assigneeID *int32
+	if task.AssigneeID.Valid {
+		assigneeID = &task.AssigneeID.Int32
+	}
+
+	return taskResponse{
+		Id:          task.ID,
+		ProjectID:   task.ProjectID,
+		Title:       task.Title,
+		Description: task.Description,
+		AssigneeID:  assigneeID,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueDate:     nullTimeToPointer(task.DueDate),
+		Labels:      task.Labels,
+		Position:    task.Position,
+		CreatedBy:   task.CreatedBy,
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+	}
+}
+
+func timePointerToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"net/http"
 	"strconv"
+	"time"
 	"yildizskylab/src/db/sqlc"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +14,7 @@ import (
 type createTeamRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description" binding:"required"`
+	ParentID    int32  `json:"parent_id" binding:"omitempty,min=1"`
 }
 
 func (s *Server) createTeam(c *gin.Context) {
@@ -21,21 +23,37 @@ func (s *Server) createTeam(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
+	if req.ParentID != 0 {
+		_, err := s.query.GetTeam(c, req.ParentID)
+
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(http.StatusNotFound, Response{
+					IsSuccess: false,
+					RequestID: requestID(c),
+					Message:   "Parent team not found",
+				})
+				return
+			}
+			dbErrorResponse(c, err)
+			return
+		}
+	}
+
 	team, err := s.query.CreateTeam(c, sqlc.CreateTeamParams{
 		Name:        req.Name,
 		Description: req.Description,
+		ParentID:    sql.NullInt32{Int32: req.ParentID, Valid: req.ParentID != 0},
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -67,6 +85,7 @@ func (s *Server) getTeam(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -78,134 +97,212 @@ func (s *Server) getTeam(c *gin.Context) {
 		if err == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, Response{
 				IsSuccess: false,
+				RequestID: requestID(c),
 				Message:   "Team not found",
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, Response{
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if ok := s.checkIfUserIsTeamLead(c, team.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
-			Message:   err.Error(),
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see this team",
 		})
 		return
 	}
 
-	leadIds, err := s.query.GetTeamLeadByTeamId(c, team.ID)
+	leads, err := s.query.GetTeamLeadsWithDetails(c, team.ID)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	var leads []returnUserResponse
+	projects, err := s.query.GetProjectsByTeamId(c, team.ID)
 
-	for _, leadId := range leadIds {
-		lead, err := s.query.GetUserWithNoDetails(c, leadId)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, Response{
-				IsSuccess: false,
-				Message:   err.Error(),
-			})
-			return
-		}
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
 
-		leadToReturn := returnUserResponse{
-			Id:              lead.ID,
-			Name:            lead.Name,
-			LastName:        lead.LastName,
-			Email:           lead.Email,
-			TelephoneNumber: lead.TelephoneNumber,
-			University:      lead.University,
-			Department:      lead.Department,
-			Role:            lead.Role,
-			DateOfBirth:     lead.DateOfBirth,
-		}
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team got successfully",
+		Data: getTeamResponse{
+			Id:          team.ID,
+			Name:        team.Name,
+			Description: team.Description,
+			TeamLeads:   newReturnUserResponses(leads),
+			Projects:    projects,
+		},
+	})
+}
 
-		leads = append(leads, leadToReturn)
-	}
+///////////////////////////////
 
-	projectIds, err := s.query.GetTeamProjectByTeamId(c, team.ID)
+// GET ALL TEAMS
+func (s *Server) getAllTeams(c *gin.Context) {
+	var req pageRequest
 
-	if err != nil {
+	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
-	var projects []sqlc.Project
-
-	for _, projectId := range projectIds {
-		project, err := s.query.GetProject(c, projectId)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, Response{
-				IsSuccess: false,
-				Message:   err.Error(),
+	teams, page, ok := listPage(c, req, pageQueries[sqlc.GetAllTeamsRow]{
+		count: func() (int64, error) {
+			return s.query.CountTeams(c)
+		},
+		byOffset: func(limit, offset int32) ([]sqlc.GetAllTeamsRow, error) {
+			return s.query.GetAllTeams(c, sqlc.GetAllTeamsParams{
+				Limit:  limit,
+				Offset: offset,
+			})
+		},
+		afterCursor: func(cursor, limit int32) ([]sqlc.GetAllTeamsRow, error) {
+			rows, err := s.query.GetAllTeamsAfterCursor(c, sqlc.GetAllTeamsAfterCursorParams{
+				ID:    cursor,
+				Limit: limit,
 			})
-		}
-		projects = append(projects, project)
-	}
 
-	if ok := s.checkIfUserIsTeamLead(c, team.ID); !ok {
-		c.JSON(http.StatusForbidden, Response{
-			IsSuccess: false,
-			Message:   "You are not authorized to see this team",
-		})
+			teams := make([]sqlc.GetAllTeamsRow, len(rows))
+
+			for i, row := range rows {
+				teams[i] = sqlc.GetAllTeamsRow(row)
+			}
+
+			return teams, err
+		},
+		id: func(team sqlc.GetAllTeamsRow) int32 {
+			return team.ID
+		},
+	})
+
+	if !ok {
 		return
 	}
 
 	c.JSON(http.StatusOK, Response{
-		IsSuccess: true,
-		Message:   "Team got successfully",
-		Data: getTeamResponse{
-			Id:          team.ID,
-			Name:        team.Name,
-			Description: team.Description,
-			TeamLeads:   leads,
-			Projects:    projects,
-		},
+		IsSuccess:  true,
+		Message:    "Teams got successfully",
+		Data:       teams,
+		Pagination: page,
 	})
 }
 
 ///////////////////////////////
 
-// GET ALL TEAMS
-type getAllTeamsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+// GET TEAM MEMBERS
+type getTeamMembersRequest struct {
+	ID       int32  `uri:"id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor   string `form:"cursor"`
 }
 
-func (s *Server) getAllTeams(c *gin.Context) {
-	var req getAllTeamsRequest
+type teamMemberResponse struct {
+	Id         int32     `json:"id"`
+	Name       string    `json:"name"`
+	LastName   string    `json:"last_name"`
+	Email      string    `json:"email"`
+	University string    `json:"university"`
+	Department string    `json:"department"`
+	TeamRole   string    `json:"team_role"`
+	JoinedAt   time.Time `json:"joined_at"`
+}
+
+func (s *Server) getTeamMembers(c *gin.Context) {
+	var req getTeamMembersRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
 
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
-	teams, err := s.query.GetAllTeams(c, sqlc.GetAllTeamsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
-	})
+	_, err := s.query.GetTeam(c, req.ID)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if ok := s.checkIfUserIsTeamMember(c, req.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
-			Message:   err.Error(),
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see members of this team",
 		})
 		return
 	}
 
+	rows, page, ok := listAfterCursor(c, cursorRequest{PageSize: req.PageSize, Cursor: req.Cursor}, pageQueries[sqlc.GetTeamMembersWithDetailsRow]{
+		count: func() (int64, error) {
+			return s.query.CountTeamMembers(c, req.ID)
+		},
+		afterCursor: func(cursor, limit int32) ([]sqlc.GetTeamMembersWithDetailsRow, error) {
+			return s.query.GetTeamMembersWithDetails(c, sqlc.GetTeamMembersWithDetailsParams{
+				TeamID: req.ID,
+				ID:     cursor,
+				Limit:  limit,
+			})
+		},
+		id: func(row sqlc.GetTeamMembersWithDetailsRow) int32 {
+			return row.ID
+		},
+	})
+
+	if !ok {
+		return
+	}
+
+	members := make([]teamMemberResponse, len(rows))
+
+	for i, row := range rows {
+		members[i] = teamMemberResponse{
+			Id:         row.UserID,
+			Name:       row.Name,
+			LastName:   row.LastName,
+			Email:      row.Email,
+			University: row.University,
+			Department: row.Department,
+			TeamRole:   row.TeamRole,
+			JoinedAt:   row.JoinedAt,
+		}
+	}
+
 	c.JSON(http.StatusOK, Response{
-		IsSuccess: true,
-		Message:   "Teams got successfully",
-		Data:      teams,
+		IsSuccess:  true,
+		Message:    "Team members got successfully",
+		Data:       members,
+		Pagination: page,
 	})
 }
 
@@ -215,6 +312,8 @@ func (s *Server) getAllTeams(c *gin.Context) {
 type updateTeamRequest struct {
 	Name        *string `json:"name"`
 	Description *string `json:"description" `
+	// LogoImageID set to 0 removes the logo
+	LogoImageID *int32 `json:"logo_image_id" binding:"omitempty,min=0"`
 }
 
 func (s *Server) updateTeam(c *gin.Context) {
@@ -223,13 +322,15 @@ func (s *Server) updateTeam(c *gin.Context) {
 
 	idParam := c.Param("id")
 
-	i, err := strconv.Atoi(idParam)
+	i, err := strconv.ParseInt(idParam, 10, 32)
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
+		return
 	}
 
 	id = int32(i)
@@ -239,6 +340,7 @@ func (s *Server) updateTeam(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -247,6 +349,7 @@ func (s *Server) updateTeam(c *gin.Context) {
 	if ok := s.checkIfUserIsTeamLead(c, id); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to update this team",
 		})
 		return
@@ -255,10 +358,16 @@ func (s *Server) updateTeam(c *gin.Context) {
 	updatedTeam, err := s.query.GetTeam(c, id)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
 	}
 
 	if req.Name != nil {
@@ -270,17 +379,26 @@ func (s *Server) updateTeam(c *gin.Context) {
 		updatedTeam.Description = *req.Description
 	}
 
+	if req.LogoImageID != nil {
+		if *req.LogoImageID != 0 {
+			if ok := s.checkImageExists(c, *req.LogoImageID); !ok {
+				return
+			}
+		}
+
+		updatedTeam.LogoImageID = sql.NullInt32{Int32: *req.LogoImageID, Valid: *req.LogoImageID != 0}
+	}
+
 	team, err := s.query.UpdateTeam(c, sqlc.UpdateTeamParams{
 		ID:          id,
 		Name:        updatedTeam.Name,
 		Description: updatedTeam.Description,
+		LogoImageID: updatedTeam.LogoImageID,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, Response{
@@ -303,6 +421,7 @@ func (s *Server) deleteTeam(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -311,18 +430,24 @@ func (s *Server) deleteTeam(c *gin.Context) {
 	if ok := s.checkIfUserIsTeamLead(c, req.ID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to delete this team",
 		})
 		return
 	}
 
-	err := s.query.DeleteTeam(c, req.ID)
+	err := s.query.DeleteTeamTx(c, req.ID)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -344,6 +469,7 @@ func (s *Server) addTeamProject(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -354,6 +480,7 @@ func (s *Server) addTeamProject(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Team not found",
 		})
 		return
@@ -364,6 +491,7 @@ func (s *Server) addTeamProject(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Project not found",
 		})
 		return
@@ -372,21 +500,23 @@ func (s *Server) addTeamProject(c *gin.Context) {
 	if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to add project to this team",
 		})
 		return
 	}
 
+	if ok := s.checkIfProjectIsApproved(c, req.ProjectID); !ok {
+		return
+	}
+
 	teamProject, err := s.query.CreateTeamProject(c, sqlc.CreateTeamProjectParams{
 		TeamID:    req.TeamID,
 		ProjectID: req.ProjectID,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -411,6 +541,7 @@ func (s *Server) removeTeamProject(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -419,6 +550,7 @@ func (s *Server) removeTeamProject(c *gin.Context) {
 	if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to remove project from this team",
 		})
 		return
@@ -430,10 +562,7 @@ func (s *Server) removeTeamProject(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -457,6 +586,7 @@ func (s *Server) addTeamMember(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -467,6 +597,7 @@ func (s *Server) addTeamMember(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Team not found",
 		})
 		return
@@ -477,6 +608,7 @@ func (s *Server) addTeamMember(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "User not found",
 		})
 		return
@@ -485,6 +617,7 @@ func (s *Server) addTeamMember(c *gin.Context) {
 	if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to add member to this team",
 		})
 		return
@@ -497,10 +630,7 @@ func (s *Server) addTeamMember(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -519,6 +649,7 @@ func (s *Server) addTeamLead(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -529,6 +660,7 @@ func (s *Server) addTeamLead(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "Team not found",
 		})
 		return
@@ -539,6 +671,7 @@ func (s *Server) addTeamLead(c *gin.Context) {
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "User not found",
 		})
 		return
@@ -547,6 +680,7 @@ func (s *Server) addTeamLead(c *gin.Context) {
 	if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to add member to this team",
 		})
 		return
@@ -559,10 +693,7 @@ func (s *Server) addTeamLead(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -585,6 +716,7 @@ func (s *Server) removeTeamMember(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -593,34 +725,164 @@ func (s *Server) removeTeamMember(c *gin.Context) {
 	if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to remove member from this team",
 		})
 		return
 	}
 
-	err := s.query.DeleteTeamMember(c, sqlc.DeleteTeamMemberParams{
+	err := s.query.RemoveTeamMemberTx(c, sqlc.DeleteTeamMemberParams{
 		TeamID: req.TeamID,
 		UserID: req.UserID,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
+		if err == sqlc.ErrLastLead {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "The last lead of a team can not be removed, transfer the leadership first",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team member removed successfully",
+	})
+}
+
+///////////////////////////////
+
+// CHANGE TEAM MEMBER ROLE
+type changeTeamMemberRoleRequest struct {
+	TeamID int32  `json:"team_id" binding:"required,min=1"`
+	UserID int32  `json:"user_id" binding:"required,min=1"`
+	Role   string `json:"role" binding:"required,oneof=lead member"`
+}
+
+func (s *Server) changeTeamMemberRole(c *gin.Context) {
+	var req changeTeamMemberRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
+	if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to change roles in this team",
+		})
+		return
+	}
+
+	// promoting to lead stays an admin decision, like addTeamLead
+	user := c.MustGet("user").(sqlc.User)
+
+	if req.Role == lead && user.Role != admin {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Only admins can promote team members to lead, use the leadership transfer instead",
+		})
+		return
+	}
+
+	member, err := s.query.ChangeTeamMemberRoleTx(c, sqlc.ChangeTeamMemberRoleTxParams{
+		TeamID: req.TeamID,
+		UserID: req.UserID,
+		Role:   req.Role,
+	})
+
+	if err != nil {
+		s.leadershipErrorResponse(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, Response{
 		IsSuccess: true,
-		Message:   "Team member removed successfully",
+		Message:   "Team member role changed successfully",
+		Data:      member,
+	})
+}
+
+///////////////////////////////
+
+// TRANSFER TEAM LEADERSHIP
+type transferTeamLeadershipRequest struct {
+	TeamID     int32 `json:"team_id" binding:"required,min=1"`
+	FromUserID int32 `json:"from_user_id" binding:"omitempty,min=1"`
+	ToUserID   int32 `json:"to_user_id" binding:"required,min=1"`
+}
+
+func (s *Server) transferTeamLeadership(c *gin.Context) {
+	var req transferTeamLeadershipRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsTeamLead(c, req.TeamID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to transfer the leadership of this team",
+		})
+		return
+	}
+
+	// leads hand over their own leadership, admins may hand over anyone's
+	user := c.MustGet("user").(sqlc.User)
+
+	if req.FromUserID == 0 || user.Role != admin {
+		req.FromUserID = user.ID
+	}
+
+	if req.FromUserID == req.ToUserID {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Leadership can not be transferred to the same user",
+		})
+		return
+	}
+
+	result, err := s.query.TransferTeamLeadershipTx(c, sqlc.TransferTeamLeadershipTxParams{
+		TeamID:     req.TeamID,
+		FromUserID: req.FromUserID,
+		ToUserID:   req.ToUserID,
+	})
+
+	if err != nil {
+		s.leadershipErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team leadership transferred successfully",
+		Data:      result,
 	})
 }
 
 ///////////////////////////////
 
 // UTILS
-// checkIfUserIsTeamLead checks if the user is team lead
+// checkIfUserIsTeamLead checks if the user is team lead or leads one of the divisions above the team
 func (s *Server) checkIfUserIsTeamLead(c *gin.Context, teamID int32) bool {
 
 	anyUser, ok := c.Get("user")
@@ -634,7 +896,7 @@ func (s *Server) checkIfUserIsTeamLead(c *gin.Context, teamID int32) bool {
 		return true
 	}
 
-	member, err := s.query.GetTeamMember(c, sqlc.GetTeamMemberParams{
+	isLead, err := s.query.IsLeadOfTeamOrAncestor(c, sqlc.IsLeadOfTeamOrAncestorParams{
 		TeamID: teamID,
 		UserID: user.ID,
 	})
@@ -643,9 +905,27 @@ func (s *Server) checkIfUserIsTeamLead(c *gin.Context, teamID int32) bool {
 		return false
 	}
 
-	if member.Role == "lead" {
+	return isLead
+}
+
+// checkIfUserIsTeamMember checks if the user is a member or a lead of the team
+func (s *Server) checkIfUserIsTeamMember(c *gin.Context, teamID int32) bool {
+
+	anyUser, ok := c.Get("user")
+	if !ok {
+		return false
+	}
+
+	user := anyUser.(sqlc.User)
+
+	if user.Role == "admin" {
 		return true
 	}
 
-	return false
+	_, err := s.query.GetTeamMember(c, sqlc.GetTeamMemberParams{
+		TeamID: teamID,
+		UserID: user.ID,
+	})
+
+	return err == nil
 }
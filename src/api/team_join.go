@@ -0,0 +1,574 @@
+package api
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CREATE TEAM JOIN REQUEST
+type createTeamJoinRequestRequest struct {
+	Message string `json:"message" binding:"max=500"`
+}
+
+func (s *Server) createTeamJoinRequest(c *gin.Context) {
+	var uri getTeamRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req createTeamJoinRequestRequest
+
+	// the message is optional, so an empty body is allowed
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	_, err := s.query.GetTeam(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	_, err = s.query.GetTeamMember(c, sqlc.GetTeamMemberParams{
+		TeamID: uri.ID,
+		UserID: user.ID,
+	})
+
+	if err == nil {
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are already a member of this team",
+		})
+		return
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	_, err = s.query.GetPendingTeamJoinRequest(c, sqlc.GetPendingTeamJoinRequestParams{
+		TeamID: uri.ID,
+		UserID: user.ID,
+	})
+
+	if err == nil {
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You already have a pending join request for this team",
+		})
+		return
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	joinRequest, err := s.query.CreateTeamJoinRequest(c, sqlc.CreateTeamJoinRequestParams{
+		TeamID:  uri.ID,
+		UserID:  user.ID,
+		Message: req.Message,
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Join request created successfully",
+		Data:      joinRequest,
+	})
+}
+
+///////////////////////////////
+
+// GET TEAM JOIN REQUESTS
+func (s *Server) getTeamJoinRequests(c *gin.Context) {
+	var req getTeamRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsTeamLead(c, req.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see join requests of this team",
+		})
+		return
+	}
+
+	joinRequests, err := s.query.GetPendingTeamJoinRequestsByTeamId(c, req.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Join requests got successfully",
+		Data:      joinRequests,
+	})
+}
+
+///////////////////////////////
+
+// APPROVE TEAM JOIN REQUEST
+type reviewTeamJoinRequestRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (s *Server) approveTeamJoinRequest(c *gin.Context) {
+	var req reviewTeamJoinRequestRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	joinRequest, ok := s.getReviewableTeamJoinRequest(c, req.ID)
+	if !ok {
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	result, err := s.query.ApproveTeamJoinRequestTx(c, sqlc.ApproveTeamJoinRequestTxParams{
+		RequestID:  joinRequest.ID,
+		ReviewerID: user.ID,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Join request was already reviewed",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Join request approved successfully",
+		Data:      result,
+	})
+}
+
+///////////////////////////////
+
+// REJECT TEAM JOIN REQUEST
+func (s *Server) rejectTeamJoinRequest(c *gin.Context) {
+	var req reviewTeamJoinRequestRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	joinRequest, ok := s.getReviewableTeamJoinRequest(c, req.ID)
+	if !ok {
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	rejected, err := s.query.ReviewTeamJoinRequest(c, sqlc.ReviewTeamJoinRequestParams{
+		ID:         joinRequest.ID,
+		Status:     "rejected",
+		ReviewedBy: sql.NullInt32{Int32: user.ID, Valid: true},
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusConflict, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Join request was already reviewed",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Join request rejected successfully",
+		Data:      rejected,
+	})
+}
+
+///////////////////////////////
+
+// CREATE TEAM INVITATION
+type createTeamInvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type teamInvitationResponse struct {
+	Id        int32     `json:"id"`
+	TeamID    int32     `json:"team_id"`
+	Email     string    `json:"email"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Token     string    `json:"token,omitempty"`
+}
+
+func (s *Server) createTeamInvitation(c *gin.Context) {
+	var uri getTeamRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req createTeamInvitationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	_, err := s.query.GetTeam(c, uri.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if ok := s.checkIfUserIsTeamLead(c, uri.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to invite members to this team",
+		})
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	// TODO: send the token by mail once the mailing system is ready
+	token := genereteUrl()
+
+	invitation, err := s.query.CreateTeamInvitation(c, sqlc.CreateTeamInvitationParams{
+		TeamID:    uri.ID,
+		Email:     strings.ToLower(req.Email),
+		TokenHash: hashInvitationToken(token),
+		InvitedBy: user.ID,
+		ExpiresAt: time.Now().Add(s.config.InvitationDuration),
+	})
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Invitation created successfully",
+		Data: teamInvitationResponse{
+			Id:        invitation.ID,
+			TeamID:    invitation.TeamID,
+			Email:     invitation.Email,
+			Status:    invitation.Status,
+			ExpiresAt: invitation.ExpiresAt,
+			Token:     token,
+		},
+	})
+}
+
+///////////////////////////////
+
+// GET TEAM INVITATIONS
+func (s *Server) getTeamInvitations(c *gin.Context) {
+	var req getTeamRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	if ok := s.checkIfUserIsTeamLead(c, req.ID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to see invitations of this team",
+		})
+		return
+	}
+
+	invitations, err := s.query.GetPendingTeamInvitationsByTeamId(c, req.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	returnInvitations := make([]teamInvitationResponse, len(invitations))
+
+	for i, invitation := range invitations {
+		returnInvitations[i] = teamInvitationResponse{
+			Id:        invitation.ID,
+			TeamID:    invitation.TeamID,
+			Email:     invitation.Email,
+			Status:    invitation.Status,
+			ExpiresAt: invitation.ExpiresAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Invitations got successfully",
+		Data:      returnInvitations,
+	})
+}
+
+///////////////////////////////
+
+// REVOKE TEAM INVITATION
+type revokeTeamInvitationRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (s *Server) revokeTeamInvitation(c *gin.Context) {
+	var req revokeTeamInvitationRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	invitation, err := s.query.GetTeamInvitation(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Invitation not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	if ok := s.checkIfUserIsTeamLead(c, invitation.TeamID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to revoke invitations of this team",
+		})
+		return
+	}
+
+	revoked, err := s.query.RevokeTeamInvitation(c, invitation.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// only pending invitations can be revoked, an accepted or expired one is left as it is
+	if revoked == 0 {
+		c.JSON(http.StatusConflict, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Invitation is no longer pending",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Invitation revoked successfully",
+	})
+}
+
+///////////////////////////////
+
+// ACCEPT TEAM INVITATION
+type acceptTeamInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (s *Server) acceptTeamInvitation(c *gin.Context) {
+	var req acceptTeamInvitationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	invitation, err := s.query.GetTeamInvitationByTokenHash(c, hashInvitationToken(req.Token))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Invitation not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	user := c.MustGet("user").(sqlc.User)
+
+	if !strings.EqualFold(invitation.Email, user.Email) {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "This invitation was sent to another email",
+		})
+		return
+	}
+
+	result, err := s.query.AcceptTeamInvitationTx(c, sqlc.AcceptTeamInvitationTxParams{
+		InvitationID: invitation.ID,
+		UserID:       user.ID,
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusGone, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Invitation has expired or is no longer valid",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Invitation accepted successfully",
+		Data:      result.Member,
+	})
+}
+
+///////////////////////////////
+
+// UTILS
+// getReviewableTeamJoinRequest loads a join request and checks that the current user leads its team,
+// it writes the error response itself and reports whether the handler can continue
+func (s *Server) getReviewableTeamJoinRequest(c *gin.Context, id int32) (sqlc.TeamJoinRequest, bool) {
+	joinRequest, err := s.query.GetTeamJoinRequest(c, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Join request not found",
+			})
+			return joinRequest, false
+		}
+		dbErrorResponse(c, err)
+		return joinRequest, false
+	}
+
+	if ok := s.checkIfUserIsTeamLead(c, joinRequest.TeamID); !ok {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "You are not authorized to review join requests of this team",
+		})
+		return joinRequest, false
+	}
+
+	return joinRequest, true
+}
+
+// hashInvitationToken hashes the token so a leaked database does not expose usable invitations
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+type publicLeadResponse struct {
+	Name     string `json:"name"`
+	LastName string `json:"last_name"`
+}
+
+// publicTeamResponse only carries the fields that may be shown to visitors of the club website
+type publicTeamResponse struct {
+	Id          int32                   `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	LogoURL     string                  `json:"logo_url"`
+	Leads       []publicLeadResponse    `json:"leads,omitempty"`
+	Projects    []publicProjectResponse `json:"projects,omitempty"`
+}
+
+type orgChartTeamResponse struct {
+	Id           int32                `json:"id"`
+	Name         string               `json:"name"`
+	LogoURL      string               `json:"logo_url"`
+	ProjectCount int64                `json:"project_count"`
+	Leads        []publicLeadResponse `json:"leads"`
+}
+
+// GET PUBLIC TEAMS
+func (s *Server) getPublicTeams(c *gin.Context) {
+	var req cursorRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	rows, page, ok := listAfterCursor(c, req, pageQueries[sqlc.GetPublicTeamsRow]{
+		count: func() (int64, error) {
+			return s.query.CountTeams(c)
+		},
+		afterCursor: func(cursor, limit int32) ([]sqlc.GetPublicTeamsRow, error) {
+			return s.query.GetPublicTeams(c, sqlc.GetPublicTeamsParams{
+				ID:    cursor,
+				Limit: limit,
+			})
+		},
+		id: func(row sqlc.GetPublicTeamsRow) int32 {
+			return row.ID
+		},
+	})
+
+	if !ok {
+		return
+	}
+
+	teams := make([]publicTeamResponse, len(rows))
+
+	for i, row := range rows {
+		teams[i] = s.newPublicTeamResponse(sqlc.GetPublicTeamRow(row))
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess:  true,
+		Message:    "Teams got successfully",
+		Data:       teams,
+		Pagination: page,
+	})
+}
+
+////////////////////////
+
+// GET PUBLIC TEAM
+func (s *Server) getPublicTeam(c *gin.Context) {
+	var req getTeamRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	row, err := s.query.GetPublicTeam(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	leads, err := s.query.GetPublicTeamLeads(c, row.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	projects, err := s.query.GetPublishedProjectsByTeamId(c, row.ID)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	team := s.newPublicTeamResponse(row)
+
+	team.Leads = make([]publicLeadResponse, len(leads))
+
+	for i, lead := range leads {
+		team.Leads[i] = publicLeadResponse{
+			Name:     lead.Name,
+			LastName: lead.LastName,
+		}
+	}
+
+	team.Projects = make([]publicProjectResponse, len(projects))
+
+	for i, project := range projects {
+		team.Projects[i] = s.newPublicProjectResponse(sqlc.GetPublishedProjectRow(project))
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team got successfully",
+		Data:      team,
+	})
+}
+
+////////////////////////
+
+// GET ORG CHART
+func (s *Server) getOrgChart(c *gin.Context) {
+	teams, err := s.query.GetOrgChartTeams(c)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	// leads of every team are read in one query and grouped here
+	leads, err := s.query.GetAllPublicTeamLeads(c)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	leadsByTeam := make(map[int32][]publicLeadResponse)
+
+	for _, lead := range leads {
+		leadsByTeam[lead.TeamID] = append(leadsByTeam[lead.TeamID], publicLeadResponse{
+			Name:     lead.Name,
+			LastName: lead.LastName,
+		})
+	}
+
+	chart := make([]orgChartTeamResponse, len(teams))
+
+	for i, team := range teams {
+		chart[i] = orgChartTeamResponse{
+			Id:           team.ID,
+			Name:         team.Name,
+			ProjectCount: team.ProjectCount,
+			Leads:        leadsByTeam[team.ID],
+		}
+
+		if team.LogoUrl.Valid {
+			chart[i].LogoURL = s.imageURL(team.LogoUrl.String)
+		}
+
+		if chart[i].Leads == nil {
+			chart[i].Leads = []publicLeadResponse{}
+		}
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Org chart got successfully",
+		Data:      chart,
+	})
+}
+
+////////////////////////
+
+// UTILS
+func (s *Server) newPublicTeamResponse(row sqlc.GetPublicTeamRow) publicTeamResponse {
+	team := publicTeamResponse{
+		Id:          row.ID,
+		Name:        row.Name,
+		Description: row.Description,
+	}
+
+	if row.LogoUrl.Valid {
+		team.LogoURL = s.imageURL(row.LogoUrl.String)
+	}
+
+	return team
+}
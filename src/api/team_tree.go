@@ -0,0 +1,144 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+type teamTreeResponse struct {
+	Id          int32              `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	LogoURL     string             `json:"logo_url"`
+	SubTeams    []teamTreeResponse `json:"sub_teams"`
+}
+
+// UPDATE TEAM PARENT
+type updateTeamParentRequest struct {
+	// ParentID set to 0 moves the team to the top level
+	ParentID *int32 `json:"parent_id" binding:"required,min=0"`
+}
+
+func (s *Server) updateTeamParent(c *gin.Context) {
+	var uri getTeamRequest
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	var req updateTeamParentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	team, err := s.query.UpdateTeamParentTx(c, sqlc.UpdateTeamParentTxParams{
+		TeamID:   uri.ID,
+		ParentID: sql.NullInt32{Int32: *req.ParentID, Valid: *req.ParentID != 0},
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "Team not found",
+			})
+			return
+		}
+		if err == sqlc.ErrTeamCycle {
+			c.JSON(http.StatusUnprocessableEntity, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "A team can not be placed under itself or one of its sub-teams",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team parent updated successfully",
+		Data:      team,
+	})
+}
+
+////////////////////////
+
+// GET TEAM TREE
+func (s *Server) getTeamTree(c *gin.Context) {
+	rows, err := s.query.GetTeamTree(c)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team tree got successfully",
+		Data:      s.buildTeamTree(rows),
+	})
+}
+
+////////////////////////
+
+// UTILS
+// buildTeamTree nests the teams under their parents,
+// teams whose parent was deleted are shown at the top level
+func (s *Server) buildTeamTree(rows []sqlc.GetTeamTreeRow) []teamTreeResponse {
+	exists := make(map[int32]bool, len(rows))
+
+	for _, row := range rows {
+		exists[row.ID] = true
+	}
+
+	children := make(map[int32][]sqlc.GetTeamTreeRow)
+	var roots []sqlc.GetTeamTreeRow
+
+	for _, row := range rows {
+		if row.ParentID.Valid && exists[row.ParentID.Int32] {
+			children[row.ParentID.Int32] = append(children[row.ParentID.Int32], row)
+		} else {
+			roots = append(roots, row)
+		}
+	}
+
+	var build func(nodes []sqlc.GetTeamTreeRow) []teamTreeResponse
+
+	build = func(nodes []sqlc.GetTeamTreeRow) []teamTreeResponse {
+		tree := make([]teamTreeResponse, len(nodes))
+
+		for i, node := range nodes {
+			tree[i] = teamTreeResponse{
+				Id:          node.ID,
+				Name:        node.Name,
+				Description: node.Description,
+				SubTeams:    build(children[node.ID]),
+			}
+
+			if node.LogoUrl.Valid {
+				tree[i].LogoURL = s.imageURL(node.LogoUrl.String)
+			}
+		}
+
+		return tree
+	}
+
+	return build(roots)
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"time"
+	"yildizskylab/src/db/sqlc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// trashPurgeInterval is how often the purge job looks for rows older than the retention window
+const trashPurgeInterval = time.Hour
+
+type trashResponse struct {
+	Teams          []sqlc.GetDeletedTeamsRow          `json:"teams"`
+	Projects       []sqlc.GetDeletedProjectsRow       `json:"projects"`
+	Users          []sqlc.GetDeletedUsersRow          `json:"users"`
+	TeamMembers    []sqlc.GetDeletedTeamMembersRow    `json:"team_members"`
+	ProjectMembers []sqlc.GetDeletedProjectMembersRow `json:"project_members"`
+}
+
+// GET TRASH
+type getTrashRequest struct {
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (s *Server) getTrash(c *gin.Context) {
+	var req getTrashRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	limit := pageSizeOrDefault(req.Limit)
+
+	teams, err := s.query.GetDeletedTeams(c, limit)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	projects, err := s.query.GetDeletedProjects(c, limit)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	users, err := s.query.GetDeletedUsers(c, limit)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	teamMembers, err := s.query.GetDeletedTeamMembers(c, limit)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	projectMembers, err := s.query.GetDeletedProjectMembers(c, limit)
+
+	if err != nil {
+		dbErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Trash got successfully",
+		Data: trashResponse{
+			Teams:          teams,
+			Projects:       projects,
+			Users:          users,
+			TeamMembers:    teamMembers,
+			ProjectMembers: projectMembers,
+		},
+	})
+}
+
+////////////////////////
+
+// RESTORE TEAM
+type restoreRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (s *Server) restoreTeam(c *gin.Context) {
+	var req restoreRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	result, err := s.query.RestoreTeamTx(c, req.ID)
+
+	if err != nil {
+		restoreErrorResponse(c, err, "Team not found in trash")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team restored successfully",
+		Data:      result,
+	})
+}
+
+////////////////////////
+
+// RESTORE PROJECT
+func (s *Server) restoreProject(c *gin.Context) {
+	var req restoreRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	result, err := s.query.RestoreProjectTx(c, req.ID)
+
+	if err != nil {
+		restoreErrorResponse(c, err, "Project not found in trash")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Project restored successfully",
+		Data:      result,
+	})
+}
+
+////////////////////////
+
+// RESTORE USER
+func (s *Server) restoreUser(c *gin.Context) {
+	var req restoreRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	result, err := s.query.RestoreUserTx(c, req.ID)
+
+	if err != nil {
+		restoreErrorResponse(c, err, "User not found in trash")
+		return
+	}
+
+	result.User.Password = ""
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "User restored successfully",
+		Data:      result,
+	})
+}
+
+////////////////////////
+
+// RESTORE TEAM MEMBER
+func (s *Server) restoreTeamMember(c *gin.Context) {
+	var req restoreRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	member, err := s.query.RestoreTeamMember(c, req.ID)
+
+	if err != nil {
+		restoreErrorResponse(c, err, "Membership not found in trash, or its team or user is deleted, or the user is already a member")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Team membership restored successfully",
+		Data:      member,
+	})
+}
+
+////////////////////////
+
+// RESTORE PROJECT MEMBER
+func (s *Server) restoreProjectMember(c *gin.Context) {
+	var req restoreRequest
+
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   err.Error(),
+		})
+		return
+	}
+
+	member, err := s.query.RestoreProjectMember(c, req.ID)
+
+	if err != nil {
+		restoreErrorResponse(c, err, "Membership not found in trash, or its project or user is deleted, or the user is already a member")
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		IsSuccess: true,
+		Message:   "Project membership restored successfully",
+		Data:      member,
+	})
+}
+
+////////////////////////
+
+// UTILS
+func restoreErrorResponse(c *gin.Context, err error, notFoundMessage string) {
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   notFoundMessage,
+		})
+		return
+	}
+
+	dbErrorResponse(c, err)
+}
+
+// runTrashPurge hard-deletes rows that stayed in the trash longer than the retention window until ctx is done
+func (s *Server) runTrashPurge(ctx context.Context) {
+	if s.config.TrashRetention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.query.PurgeTrashTx(ctx, time.Now().Add(-s.config.TrashRetention))
+
+		if err != nil {
+			slog.Error("trash purge failed", "error", err)
+		} else {
+			slog.Info("trash purge finished", "result", result)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"database/sql"
+	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -48,34 +51,32 @@ func (s *Server) signup(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
+	// signups for an email are limited apart from its logins, so they can not use up the login attempts of the account
+	if ok := s.allowAttempt(c, s.signupLimiter, accountKey(req.Email)); !ok {
+		return
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), 10)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	var user sqlc.User
-
 	isExists, existedUser := s.checkUserIfNotExistByEmail(c, req.Email)
 
 	switch isExists {
 	case exists:
-		c.JSON(http.StatusConflict, Response{
-			IsSuccess: false,
-			Message:   "User already exists",
-		})
-		return
+		// answered like a new signup, so the endpoint can not be used to find out which emails have accounts
+		slog.InfoContext(c, "signup for a registered email", "request_id", requestID(c))
 	case notExists:
-		user, err = s.query.CreateUser(c, sqlc.CreateUserParams{
+		_, err = s.query.CreateUser(c, sqlc.CreateUserParams{
 			Name:            req.Name,
 			LastName:        req.LastName,
 			Email:           req.Email,
@@ -88,31 +89,24 @@ func (s *Server) signup(c *gin.Context) {
 		})
 
 	case deleted:
-		user, err = s.overwriteUser(c, existedUser)
+		_, err = s.overwriteUser(c, existedUser.ID, req, string(hash))
+	}
+
+	// a concurrent signup with the same email loses on the unique index, it gets the same answer as well
+	var pqErr *pq.Error
+
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == "users_email_active_idx" {
+		err = nil
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, Response{
 		IsSuccess: true,
-		Message:   "User created successfully",
-		Data: returnUserResponse{
-			Id:              user.ID,
-			Name:            user.Name,
-			LastName:        user.LastName,
-			Email:           user.Email,
-			TelephoneNumber: user.TelephoneNumber,
-			University:      user.University,
-			Department:      user.Department,
-			DateOfBirth:     user.DateOfBirth,
-			Role:            user.Role,
-		},
+		Message:   "Signup received, you can log in with your email and password",
 	})
 }
 
@@ -131,52 +125,65 @@ func (s *Server) login(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
+	account := accountKey(req.Email)
+
+	if ok := s.allowAttempt(c, s.accountLimiter, account); !ok {
+		return
+	}
+
+	if ok := s.checkAccountLock(c, account); !ok {
+		return
+	}
+
 	user, err := s.query.GetUserByEmail(c, req.Email)
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			c.JSON(http.StatusNotFound, Response{
-				IsSuccess: false,
-				Message:   "User not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+	if err != nil && err != sql.ErrNoRows {
+		dbErrorResponse(c, err)
 		return
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	// unknown emails are checked against a dummy hash and get the same answer as a wrong password,
+	// so neither the response nor its timing tells whether the account exists
+	found := err == nil
+	hash := dummyPasswordHash
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
+	if found {
+		hash = user.Password
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password))
+
+	if !found || err != nil {
+		s.loginFailed(c, account)
+		c.JSON(http.StatusUnauthorized, Response{
 			IsSuccess: false,
-			Message:   "Invalid password",
+			RequestID: requestID(c),
+			Message:   "Invalid email or password",
 		})
 		return
 	}
 
+	if err := s.lockout.Reset(c, account); err != nil {
+		slog.ErrorContext(c, "resetting failed logins failed", "request_id", requestID(c), "error", err)
+	}
+
 	// generate a jwt toke
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub": user.UserID,
-		"exp": time.Now().Add(time.Hour * 24).Unix(),
+		"exp": time.Now().Add(s.config.AccessTokenDuration).Unix(),
 	})
 
-	tokenString, err := token.SignedString([]byte(s.secret))
+	tokenString, err := token.SignedString([]byte(s.config.Secret))
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -214,6 +221,7 @@ func (s *Server) getUser(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -222,6 +230,7 @@ func (s *Server) getUser(c *gin.Context) {
 	if ok := s.checkUserPermission(c, req.ID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to see this user",
 		})
 		return
@@ -233,69 +242,29 @@ func (s *Server) getUser(c *gin.Context) {
 		if err == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, Response{
 				IsSuccess: false,
+				RequestID: requestID(c),
 				Message:   "User not found",
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	teamIds, err := s.query.GetTeamsByUserId(c, user.ID)
+	teams, err := s.query.GetTeamsByUserId(c, user.ID)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	var teams []sqlc.Team
-
-	for _, teamId := range teamIds {
-		team, err := s.query.GetTeam(c, teamId)
-
-		if err != nil {
-			c.JSON(http.StatusBadRequest, Response{
-				IsSuccess: false,
-				Message:   err.Error(),
-			})
-			return
-		}
-
-		teams = append(teams, team)
-	}
-
-	projectIds, err := s.query.GetProjectsByUserId(c, user.ID)
+	projects, err := s.query.GetProjectsByUserId(c, user.ID)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
 		return
 	}
 
-	var projects []sqlc.Project
-
-	for _, projectId := range projectIds {
-		project, err := s.query.GetProject(c, projectId)
-
-		if err != nil {
-			c.JSON(http.StatusBadRequest, Response{
-				IsSuccess: false,
-				Message:   err.Error(),
-			})
-			return
-		}
-
-		projects = append(projects, project)
-	}
-
 	c.JSON(http.StatusOK, Response{
 		IsSuccess: true,
 		Message:   "User got successfully",
@@ -318,55 +287,51 @@ func (s *Server) getUser(c *gin.Context) {
 ////////////////////////
 
 // GET ALL USERS
-type getAllUsersRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
-}
 
 func (s *Server) getAllUsers(c *gin.Context) {
-	var req getAllUsersRequest
+	var req pageRequest
 
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
 	}
 
-	users, err := s.query.GetAllUsers(c, sqlc.GetAllUsersParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+	users, page, ok := listPage(c, req, pageQueries[sqlc.User]{
+		count: func() (int64, error) {
+			return s.query.CountUsers(c)
+		},
+		byOffset: func(limit, offset int32) ([]sqlc.User, error) {
+			return s.query.GetAllUsers(c, sqlc.GetAllUsersParams{
+				Limit:  limit,
+				Offset: offset,
+			})
+		},
+		afterCursor: func(cursor, limit int32) ([]sqlc.User, error) {
+			return s.query.GetAllUsersAfterCursor(c, sqlc.GetAllUsersAfterCursorParams{
+				ID:    cursor,
+				Limit: limit,
+			})
+		},
+		id: func(user sqlc.User) int32 {
+			return user.ID
+		},
 	})
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+	if !ok {
 		return
 	}
 
-	returnUsers := make([]returnUserResponse, len(users))
-
-	for i, user := range users {
-		returnUsers[i] = returnUserResponse{
-			Id:              user.ID,
-			Name:            user.Name,
-			LastName:        user.LastName,
-			Email:           user.Email,
-			TelephoneNumber: user.TelephoneNumber,
-			University:      user.University,
-			Department:      user.Department,
-			DateOfBirth:     user.DateOfBirth,
-			Role:            user.Role,
-		}
-	}
+	returnUsers := newReturnUserResponses(users)
 
 	c.JSON(http.StatusOK, Response{
-		IsSuccess: true,
-		Message:   "Users got successfully",
-		Data:      returnUsers,
+		IsSuccess:  true,
+		Message:    "Users got successfully",
+		Data:       returnUsers,
+		Pagination: page,
 	})
 }
 
@@ -390,13 +355,15 @@ func (s *Server) updateUser(c *gin.Context) {
 
 	idParam := c.Param("id")
 
-	i, err := strconv.Atoi(idParam)
+	i, err := strconv.ParseInt(idParam, 10, 32)
 
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
+		return
 	}
 
 	id = int32(i)
@@ -406,6 +373,7 @@ func (s *Server) updateUser(c *gin.Context) {
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -414,18 +382,34 @@ func (s *Server) updateUser(c *gin.Context) {
 	if ok := s.checkUserPermission(c, id); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to update this user",
 		})
 		return
 	}
 
+	// a user can edit their own profile but not their own role
+	if user := c.MustGet("user").(sqlc.User); req.Role != nil && user.Role != admin {
+		c.JSON(http.StatusForbidden, Response{
+			IsSuccess: false,
+			RequestID: requestID(c),
+			Message:   "Only admins can change the role of a user",
+		})
+		return
+	}
+
 	updatedUser, err := s.query.GetUser(c, id)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "User not found",
+			})
+			return
+		}
+		dbErrorResponse(c, err)
 		return
 	}
 
@@ -442,7 +426,14 @@ func (s *Server) updateUser(c *gin.Context) {
 	}
 
 	if req.Password != nil {
-		updatedUser.Password = *req.Password
+		hash, err := bcrypt.GenerateFromPassword([]byte(*req.Password), 10)
+
+		if err != nil {
+			dbErrorResponse(c, err)
+			return
+		}
+
+		updatedUser.Password = string(hash)
 	}
 
 	if req.TelephoneNumber != nil {
@@ -479,10 +470,8 @@ func (s *Server) updateUser(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		dbErrorResponse(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, Response{
@@ -515,6 +504,7 @@ func (s *Server) deleteUser(c *gin.Context) {
 	if err := c.ShouldBindUri(&req); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   err.Error(),
 		})
 		return
@@ -523,38 +513,24 @@ func (s *Server) deleteUser(c *gin.Context) {
 	if ok := s.checkUserPermission(c, req.ID); !ok {
 		c.JSON(http.StatusForbidden, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "You are not authorized to delete this user",
 		})
 		return
 	}
 
-	err := s.query.DeleteProjectMemberByUserId(c, req.ID)
+	err := s.query.DeleteUserTx(c, req.ID)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
-		return
-	}
-
-	err = s.query.DeleteTeamMemberByUserId(c, req.ID)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
-		return
-	}
-
-	err = s.query.DeleteUser(c, req.ID)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, Response{
-			IsSuccess: false,
-			Message:   err.Error(),
-		})
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, Response{
+				IsSuccess: false,
+				RequestID: requestID(c),
+				Message:   "User not found",
+			})
+			return
+		}
+		s.leadershipErrorResponse(c, err)
 		return
 	}
 
@@ -573,6 +549,7 @@ func (s *Server) currentUser(c *gin.Context) {
 	if !ok {
 		c.JSON(http.StatusInternalServerError, Response{
 			IsSuccess: false,
+			RequestID: requestID(c),
 			Message:   "user not found",
 		})
 	}
@@ -613,18 +590,19 @@ func (s *Server) checkUserIfNotExistByEmail(c *gin.Context, email string) (int,
 	return exists, user
 }
 
-func (s *Server) overwriteUser(c *gin.Context, user sqlc.User) (sqlc.User, error) {
+// overwriteUser reuses the row of a deleted account for a new signup, nothing of the old account is kept
+func (s *Server) overwriteUser(c *gin.Context, id int32, req signupRequest, hash string) (sqlc.User, error) {
 
 	arg := sqlc.OverwriteUserParams{
-		ID:              user.ID,
-		Name:            user.Name,
-		LastName:        user.LastName,
-		Email:           user.Email,
-		Password:        user.Password,
-		TelephoneNumber: user.TelephoneNumber,
-		University:      user.University,
-		Department:      user.Department,
-		DateOfBirth:     user.DateOfBirth,
+		ID:              id,
+		Name:            req.Name,
+		LastName:        req.LastName,
+		Email:           req.Email,
+		Password:        hash,
+		TelephoneNumber: req.TelephoneNumber,
+		University:      req.University,
+		Department:      req.Department,
+		DateOfBirth:     req.DateOfBirth,
 		Role:            "member",
 	}
 
@@ -651,3 +629,23 @@ func (s *Server) checkUserPermission(c *gin.Context, userId int32) bool {
 	return false
 
 }
+
+func newReturnUserResponses(users []sqlc.User) []returnUserResponse {
+	returnUsers := make([]returnUserResponse, len(users))
+
+	for i, user := range users {
+		returnUsers[i] = returnUserResponse{
+			Id:              user.ID,
+			Name:            user.Name,
+			LastName:        user.LastName,
+			Email:           user.Email,
+			TelephoneNumber: user.TelephoneNumber,
+			University:      user.University,
+			Department:      user.Department,
+			DateOfBirth:     user.DateOfBirth,
+			Role:            user.Role,
+		}
+	}
+
+	return returnUsers
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"yildizskylab/src/db/sqlc"
+	"yildizskylab/src/util"
+
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/term"
+)
+
+const minPasswordLength = 8
+
+var stdin = bufio.NewReader(os.Stdin)
+
+// CREATE ADMIN
+// every flag falls back to an env variable so the command can run in a container without a terminal
+func createAdmin(config util.Config, args []string) error {
+	flags := newFlagSet("create-admin")
+	email := flags.String("email", os.Getenv("ADMIN_EMAIL"), "email of the admin, defaults to $ADMIN_EMAIL")
+	name := flags.String("name", os.Getenv("ADMIN_NAME"), "name of the admin, defaults to $ADMIN_NAME")
+	lastName := flags.String("last-name", os.Getenv("ADMIN_LAST_NAME"), "last name of the admin, defaults to $ADMIN_LAST_NAME")
+	phone := flags.String("phone", os.Getenv("ADMIN_TELEPHONE_NUMBER"), "telephone number of the admin, defaults to $ADMIN_TELEPHONE_NUMBER")
+	university := flags.String("university", os.Getenv("ADMIN_UNIVERSITY"), "university of the admin, defaults to $ADMIN_UNIVERSITY")
+	department := flags.String("department", os.Getenv("ADMIN_DEPARTMENT"), "department of the admin, defaults to $ADMIN_DEPARTMENT")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	for _, field := range []struct {
+		label string
+		value *string
+	}{
+		{"Email", email},
+		{"Name", name},
+		{"Last name", lastName},
+	} {
+		if err := promptIfEmpty(field.label, field.value); err != nil {
+			return err
+		}
+	}
+
+	password, err := readPassword("ADMIN_PASSWORD")
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+	if err != nil {
+		return err
+	}
+
+	store, db, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	user, err := store.CreateUser(context.Background(), sqlc.CreateUserParams{
+		Name:            *name,
+		LastName:        *lastName,
+		Email:           *email,
+		Password:        string(hash),
+		TelephoneNumber: *phone,
+		Role:            "admin",
+		University:      *university,
+		Department:      *department,
+		DateOfBirth:     time.Now(),
+	})
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return fmt.Errorf("a user with email %s already exists, use reset-password to change its password", *email)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("admin %s created with id %d\n", user.Email, user.ID)
+
+	return nil
+}
+
+////////////////////////
+
+// RESET PASSWORD
+func resetPassword(config util.Config, args []string) error {
+	flags := newFlagSet("reset-password")
+	email := flags.String("email", "", "email of the user")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := promptIfEmpty("Email", email); err != nil {
+		return err
+	}
+
+	password, err := readPassword("NEW_PASSWORD")
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
+	if err != nil {
+		return err
+	}
+
+	store, db, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	user, err := store.UpdateUserPassword(context.Background(), sqlc.UpdateUserPasswordParams{
+		Email:    *email,
+		Password: string(hash),
+	})
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no user with email %s", *email)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("password of %s updated\n", user.Email)
+
+	return nil
+}
+
+////////////////////////
+
+// UTILS
+// promptIfEmpty asks for a missing value, without a terminal the value has to come from a flag or env variable
+func promptIfEmpty(label string, value *string) error {
+	if strings.TrimSpace(*value) != "" {
+		return nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("%s is required", strings.ToLower(label))
+	}
+
+	fmt.Printf("%s: ", label)
+
+	line, err := stdin.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	*value = strings.TrimSpace(line)
+
+	if *value == "" {
+		return fmt.Errorf("%s is required", strings.ToLower(label))
+	}
+
+	return nil
+}
+
+// readPassword takes the password from envKey or asks for it twice without echoing it
+func readPassword(envKey string) (string, error) {
+	password := os.Getenv(envKey)
+
+	if password == "" {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return "", fmt.Errorf("password is required, set %s or run the command in a terminal", envKey)
+		}
+
+		fmt.Print("Password: ")
+		first, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Print("Repeat password: ")
+		second, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", err
+		}
+
+		if string(first) != string(second) {
+			return "", errors.New("passwords do not match")
+		}
+
+		password = string(first)
+	}
+
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+
+	return password, nil
+}
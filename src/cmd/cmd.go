@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"yildizskylab/src/db/sqlc"
+	"yildizskylab/src/telemetry"
+	"yildizskylab/src/util"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(config util.Config, args []string) error
+}
+
+var commands = []command{
+	{name: "serve", usage: "start the api server", run: serve},
+	{name: "migrate", usage: "apply or revert the embedded database migrations", run: migrateDatabase},
+	{name: "create-admin", usage: "create an admin user", run: createAdmin},
+	{name: "reset-password", usage: "set a new password for a user", run: resetPassword},
+}
+
+// logLevel is changed once the config is loaded, until then everything at info level is logged
+var logLevel slog.LevelVar
+
+// Run executes the subcommand named by the first argument, the server is started when there is none
+func Run(args []string) error {
+	slog.SetDefault(slog.New(telemetry.LogHandler{
+		Handler: slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: &logLevel}),
+	}))
+
+	name := "serve"
+
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return nil
+	}
+
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+
+		config, err := util.LoadConfig(".")
+		if err != nil {
+			return err
+		}
+
+		if err = logLevel.UnmarshalText([]byte(config.LogLevel)); err != nil {
+			return err
+		}
+
+		err = c.run(config, args)
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+
+		return err
+	}
+
+	printUsage()
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0])
+
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", c.name, c.usage)
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// openStore connects to the database and checks that it is reachable
+func openStore(config util.Config) (*sqlc.Store, *sql.DB, error) {
+	db, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return sqlc.NewStore(db), db, nil
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"yildizskylab/src/db/migration"
+	"yildizskylab/src/util"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+const migrateUsage = "usage: migrate up [n] | down [n] | down all | status | version | force <version>"
+
+// MIGRATE
+// up without n applies every pending migration, down without n only reverts the last one
+func migrateDatabase(config util.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	m, err := migration.New(config.DBSource)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	action, args := args[0], args[1:]
+
+	switch action {
+	case "up":
+		n, err := stepCount(args, -1)
+		if err != nil {
+			return err
+		}
+
+		if n < 0 {
+			err = m.Up()
+		} else {
+			err = m.Steps(n)
+		}
+
+		if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return err
+		}
+	case "down":
+		if len(args) == 1 && args[0] == "all" {
+			err = m.Down()
+		} else {
+			var n int
+
+			n, err = stepCount(args, 1)
+			if err != nil {
+				return err
+			}
+
+			err = m.Steps(-n)
+		}
+
+		if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return err
+		}
+	case "status":
+		return printMigrationStatus(m)
+	case "version":
+	case "force":
+		if len(args) != 1 {
+			return errors.New(migrateUsage)
+		}
+
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+
+		if err = m.Force(version); err != nil {
+			return err
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return printMigrationVersion(m)
+}
+
+////////////////////////
+
+// UTILS
+func stepCount(args []string, fallback int) (int, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid step count %q", args[0])
+	}
+
+	return n, nil
+}
+
+func printMigrationVersion(m *migrate.Migrate) error {
+	version, dirty, err := migration.Version(m)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		fmt.Printf("version %d (dirty, fix the database and run migrate force %d)\n", version, version)
+		return nil
+	}
+
+	fmt.Printf("version %d\n", version)
+
+	return nil
+}
+
+func printMigrationStatus(m *migrate.Migrate) error {
+	version, dirty, err := migration.Version(m)
+	if err != nil {
+		return err
+	}
+
+	list, err := migration.List(version)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range list {
+		state := "pending"
+
+		if status.Applied {
+			state = "applied"
+		}
+
+		if dirty && status.Version == version {
+			state = "dirty"
+		}
+
+		fmt.Printf("%-8s %06d_%s\n", state, status.Version, status.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
+	"yildizskylab/src/api"
+	"yildizskylab/src/db/migration"
+	"yildizskylab/src/ratelimit"
+	"yildizskylab/src/telemetry"
+	"yildizskylab/src/util"
+)
+
+func serve(config util.Config, args []string) error {
+	if err := newFlagSet("serve").Parse(args); err != nil {
+		return err
+	}
+
+	if config.AutoMigrate {
+		slog.Info("applying database migrations")
+
+		if err := migration.Up(config.DBSource); err != nil {
+			return err
+		}
+	}
+
+	shutdownTracing, err := telemetry.Setup(context.Background(), config)
+	if err != nil {
+		return err
+	}
+
+	// flushes the buffered spans after the server stopped
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("flushing traces failed", "error", err)
+		}
+	}()
+
+	store, db, err := openStore(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// the server drains the requests in flight once SIGTERM or SIGINT cancels ctx
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	limits, err := ratelimit.NewStore(config)
+	if err != nil {
+		return err
+	}
+	defer limits.Close()
+
+	server, err := api.NewServer(store, limits, config)
+	if err != nil {
+		return err
+	}
+
+	return server.Start(ctx)
+}
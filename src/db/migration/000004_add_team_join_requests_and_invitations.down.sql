@@ -0,0 +1,2 @@
+DROP TABLE team_invitations;
+DROP TABLE team_join_requests;
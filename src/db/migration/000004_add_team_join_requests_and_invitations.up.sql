@@ -0,0 +1,38 @@
+CREATE TABLE "team_join_requests" (
+  "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "team_id" int NOT NULL,
+  "user_id" int NOT NULL,
+  "message" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "reviewed_by" int,
+  "reviewed_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT(NOW()),
+  "updated_at" timestamp NOT NULL DEFAULT(NOW()),
+  "deleted_at" timestamp
+);
+
+CREATE TABLE "team_invitations" (
+  "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "team_id" int NOT NULL,
+  "email" varchar NOT NULL,
+  "token_hash" varchar NOT NULL UNIQUE,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "invited_by" int NOT NULL,
+  "accepted_by" int,
+  "expires_at" timestamp NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT(NOW()),
+  "updated_at" timestamp NOT NULL DEFAULT(NOW()),
+  "deleted_at" timestamp
+);
+
+ALTER TABLE "team_join_requests" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id");
+
+ALTER TABLE "team_join_requests" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "team_join_requests" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id");
+
+ALTER TABLE "team_invitations" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id");
+
+ALTER TABLE "team_invitations" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("id");
+
+ALTER TABLE "team_invitations" ADD FOREIGN KEY ("accepted_by") REFERENCES "users" ("id");
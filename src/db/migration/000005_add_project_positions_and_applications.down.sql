@@ -0,0 +1,2 @@
+DROP TABLE project_applications;
+DROP TABLE project_positions;
@@ -0,0 +1,37 @@
+CREATE TABLE "project_positions" (
+  "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "project_id" int NOT NULL,
+  "role" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "skills" varchar[] NOT NULL DEFAULT '{}',
+  "slots" int NOT NULL DEFAULT 1,
+  "filled_slots" int NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'open',
+  "created_by" int NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT(NOW()),
+  "updated_at" timestamp NOT NULL DEFAULT(NOW()),
+  "deleted_at" timestamp
+);
+
+CREATE TABLE "project_applications" (
+  "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "position_id" int NOT NULL,
+  "user_id" int NOT NULL,
+  "motivation" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "reviewed_by" int,
+  "reviewed_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT(NOW()),
+  "updated_at" timestamp NOT NULL DEFAULT(NOW()),
+  "deleted_at" timestamp
+);
+
+ALTER TABLE "project_positions" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id");
+
+ALTER TABLE "project_positions" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+ALTER TABLE "project_applications" ADD FOREIGN KEY ("position_id") REFERENCES "project_positions" ("id");
+
+ALTER TABLE "project_applications" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "project_applications" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id");
@@ -0,0 +1,3 @@
+ALTER TABLE "projects" DROP COLUMN "approval_status";
+
+ALTER TABLE "projects" DROP COLUMN "created_by";
@@ -0,0 +1,5 @@
+ALTER TABLE "projects" ADD COLUMN "created_by" int;
+
+ALTER TABLE "projects" ADD COLUMN "approval_status" varchar NOT NULL DEFAULT 'approved';
+
+ALTER TABLE "projects" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
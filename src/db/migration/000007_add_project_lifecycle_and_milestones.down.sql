@@ -0,0 +1,7 @@
+DROP TABLE project_milestones;
+
+ALTER TABLE "projects" DROP COLUMN "end_date";
+
+ALTER TABLE "projects" DROP COLUMN "start_date";
+
+ALTER TABLE "projects" DROP COLUMN "status";
@@ -0,0 +1,19 @@
+ALTER TABLE "projects" ADD COLUMN "status" varchar NOT NULL DEFAULT 'proposed';
+
+ALTER TABLE "projects" ADD COLUMN "start_date" timestamp;
+
+ALTER TABLE "projects" ADD COLUMN "end_date" timestamp;
+
+CREATE TABLE "project_milestones" (
+  "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "project_id" int NOT NULL,
+  "title" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "due_date" timestamp NOT NULL,
+  "completed_at" timestamp,
+  "created_at" timestamp NOT NULL DEFAULT(NOW()),
+  "updated_at" timestamp NOT NULL DEFAULT(NOW()),
+  "deleted_at" timestamp
+);
+
+ALTER TABLE "project_milestones" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id");
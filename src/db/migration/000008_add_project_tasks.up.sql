@@ -0,0 +1,26 @@
+CREATE TABLE "project_tasks" (
+  "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "project_id" int NOT NULL,
+  "title" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "assignee_id" int,
+  "status" varchar NOT NULL DEFAULT 'todo',
+  "priority" varchar NOT NULL DEFAULT 'medium',
+  "due_date" timestamp,
+  "labels" varchar[] NOT NULL DEFAULT '{}',
+  "position" int NOT NULL DEFAULT 0,
+  "created_by" int NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT(NOW()),
+  "updated_at" timestamp NOT NULL DEFAULT(NOW()),
+  "deleted_at" timestamp
+);
+
+CREATE INDEX ON "project_tasks" ("project_id", "status", "position");
+
+CREATE INDEX ON "project_tasks" ("assignee_id");
+
+ALTER TABLE "project_tasks" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id");
+
+ALTER TABLE "project_tasks" ADD FOREIGN KEY ("assignee_id") REFERENCES "users" ("id");
+
+ALTER TABLE "project_tasks" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
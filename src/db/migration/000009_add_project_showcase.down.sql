@@ -0,0 +1,13 @@
+DROP TABLE project_images;
+
+ALTER TABLE "projects" DROP COLUMN "is_published";
+
+ALTER TABLE "projects" DROP COLUMN "cover_image_id";
+
+ALTER TABLE "projects" DROP COLUMN "demo_url";
+
+ALTER TABLE "projects" DROP COLUMN "repository_url";
+
+ALTER TABLE "projects" DROP COLUMN "tech_stack";
+
+ALTER TABLE "projects" DROP COLUMN "summary";
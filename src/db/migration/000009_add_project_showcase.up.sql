@@ -0,0 +1,26 @@
+ALTER TABLE "projects" ADD COLUMN "summary" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "projects" ADD COLUMN "tech_stack" varchar[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE "projects" ADD COLUMN "repository_url" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "projects" ADD COLUMN "demo_url" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "projects" ADD COLUMN "cover_image_id" int;
+
+ALTER TABLE "projects" ADD COLUMN "is_published" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "project_images" (
+  "id" INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  "project_id" int NOT NULL,
+  "image_id" int NOT NULL,
+  "created_at" timestamp NOT NULL DEFAULT(NOW()),
+  "updated_at" timestamp NOT NULL DEFAULT(NOW()),
+  "deleted_at" timestamp
+);
+
+ALTER TABLE "projects" ADD FOREIGN KEY ("cover_image_id") REFERENCES "images" ("id");
+
+ALTER TABLE "project_images" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id");
+
+ALTER TABLE "project_images" ADD FOREIGN KEY ("image_id") REFERENCES "images" ("id");
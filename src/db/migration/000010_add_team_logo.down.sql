@@ -0,0 +1 @@
+ALTER TABLE "teams" DROP COLUMN "logo_image_id";
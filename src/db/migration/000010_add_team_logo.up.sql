@@ -0,0 +1,3 @@
+ALTER TABLE "teams" ADD COLUMN "logo_image_id" int;
+
+ALTER TABLE "teams" ADD FOREIGN KEY ("logo_image_id") REFERENCES "images" ("id");
@@ -0,0 +1,5 @@
+ALTER TABLE "teams" ADD COLUMN "parent_id" int;
+
+CREATE INDEX ON "teams" ("parent_id");
+
+ALTER TABLE "teams" ADD FOREIGN KEY ("parent_id") REFERENCES "teams" ("id");
@@ -0,0 +1,65 @@
+ALTER TABLE "team_users" DROP CONSTRAINT "team_users_team_id_fkey";
+ALTER TABLE "team_users" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_users" DROP CONSTRAINT "team_users_user_id_fkey";
+ALTER TABLE "team_users" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "project_users" DROP CONSTRAINT "project_users_project_id_fkey";
+ALTER TABLE "project_users" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "project_users" DROP CONSTRAINT "project_users_user_id_fkey";
+ALTER TABLE "project_users" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_projects" DROP CONSTRAINT "team_projects_team_id_fkey";
+ALTER TABLE "team_projects" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_projects" DROP CONSTRAINT "team_projects_project_id_fkey";
+ALTER TABLE "team_projects" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "teams" DROP CONSTRAINT "teams_parent_id_fkey";
+ALTER TABLE "teams" ADD FOREIGN KEY ("parent_id") REFERENCES "teams" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "team_join_requests" DROP CONSTRAINT "team_join_requests_team_id_fkey";
+ALTER TABLE "team_join_requests" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_join_requests" DROP CONSTRAINT "team_join_requests_user_id_fkey";
+ALTER TABLE "team_join_requests" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_join_requests" DROP CONSTRAINT "team_join_requests_reviewed_by_fkey";
+ALTER TABLE "team_join_requests" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "team_invitations" DROP CONSTRAINT "team_invitations_team_id_fkey";
+ALTER TABLE "team_invitations" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "team_invitations" DROP CONSTRAINT "team_invitations_accepted_by_fkey";
+ALTER TABLE "team_invitations" ADD FOREIGN KEY ("accepted_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "projects" DROP CONSTRAINT "projects_created_by_fkey";
+ALTER TABLE "projects" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "project_positions" DROP CONSTRAINT "project_positions_project_id_fkey";
+ALTER TABLE "project_positions" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "project_applications" DROP CONSTRAINT "project_applications_position_id_fkey";
+ALTER TABLE "project_applications" ADD FOREIGN KEY ("position_id") REFERENCES "project_positions" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "project_applications" DROP CONSTRAINT "project_applications_user_id_fkey";
+ALTER TABLE "project_applications" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "project_applications" DROP CONSTRAINT "project_applications_reviewed_by_fkey";
+ALTER TABLE "project_applications" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "project_milestones" DROP CONSTRAINT "project_milestones_project_id_fkey";
+ALTER TABLE "project_milestones" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "project_tasks" DROP CONSTRAINT "project_tasks_project_id_fkey";
+ALTER TABLE "project_tasks" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "project_tasks" DROP CONSTRAINT "project_tasks_assignee_id_fkey";
+ALTER TABLE "project_tasks" ADD FOREIGN KEY ("assignee_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "project_images" DROP CONSTRAINT "project_images_project_id_fkey";
+ALTER TABLE "project_images" ADD FOREIGN KEY ("project_id") REFERENCES "projects" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "news" DROP CONSTRAINT "fk_created_by";
+ALTER TABLE "news" ADD CONSTRAINT "fk_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users" ("id") ON DELETE SET NULL;
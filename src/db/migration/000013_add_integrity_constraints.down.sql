@@ -0,0 +1,15 @@
+ALTER TABLE "images" DROP CONSTRAINT "images_created_by_fkey";
+
+CREATE SEQUENCE "images_created_by_seq" OWNED BY "images"."created_by";
+
+UPDATE images SET created_by = nextval('images_created_by_seq') WHERE created_by IS NULL;
+
+ALTER TABLE "images" ALTER COLUMN "created_by" SET DEFAULT nextval('images_created_by_seq');
+
+ALTER TABLE "images" ALTER COLUMN "created_by" SET NOT NULL;
+
+DROP INDEX "project_users_project_id_user_id_active_idx";
+
+DROP INDEX "team_users_team_id_user_id_active_idx";
+
+DROP INDEX "users_email_active_idx";
@@ -0,0 +1,53 @@
+-- keep the oldest active account of every email, emails are compared without case like at login.
+-- The memberships of the newer copies move to the kept account before the copies go to the trash
+CREATE TEMPORARY TABLE duplicate_users AS
+SELECT id, kept_id FROM (
+  SELECT id, MIN(id) OVER (PARTITION BY lower(email)) AS kept_id
+  FROM users WHERE deleted_at IS NULL
+) ranked WHERE id <> kept_id;
+
+UPDATE team_users tu SET user_id = d.kept_id, updated_at = NOW()
+FROM duplicate_users d
+WHERE tu.user_id = d.id AND tu.deleted_at IS NULL;
+
+UPDATE project_users pu SET user_id = d.kept_id, updated_at = NOW()
+FROM duplicate_users d
+WHERE pu.user_id = d.id AND pu.deleted_at IS NULL;
+
+UPDATE users SET deleted_at = NOW() WHERE id IN (SELECT id FROM duplicate_users);
+
+DROP TABLE duplicate_users;
+
+-- keep one active membership per pair, preferring the lead row, merged accounts can hold both
+WITH duplicates AS (
+  SELECT id FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY team_id, user_id ORDER BY role = 'lead' DESC, id) AS n
+    FROM team_users WHERE deleted_at IS NULL
+  ) ranked WHERE n > 1
+)
+UPDATE team_users SET deleted_at = NOW() WHERE id IN (SELECT id FROM duplicates);
+
+WITH duplicates AS (
+  SELECT id FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id, user_id ORDER BY role = 'lead' DESC, id) AS n
+    FROM project_users WHERE deleted_at IS NULL
+  ) ranked WHERE n > 1
+)
+UPDATE project_users SET deleted_at = NOW() WHERE id IN (SELECT id FROM duplicates);
+
+CREATE UNIQUE INDEX "users_email_active_idx" ON "users" (lower("email")) WHERE "deleted_at" IS NULL;
+
+CREATE UNIQUE INDEX "team_users_team_id_user_id_active_idx" ON "team_users" ("team_id", "user_id") WHERE "deleted_at" IS NULL;
+
+CREATE UNIQUE INDEX "project_users_project_id_user_id_active_idx" ON "project_users" ("project_id", "user_id") WHERE "deleted_at" IS NULL;
+
+-- created_by was declared as SERIAL, so it had its own sequence instead of pointing at users
+ALTER TABLE "images" ALTER COLUMN "created_by" DROP DEFAULT;
+
+DROP SEQUENCE IF EXISTS "images_created_by_seq";
+
+ALTER TABLE "images" ALTER COLUMN "created_by" DROP NOT NULL;
+
+UPDATE images SET created_by = NULL WHERE created_by NOT IN (SELECT id FROM users);
+
+ALTER TABLE "images" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id") ON DELETE SET NULL;
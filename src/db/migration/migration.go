@@ -0,0 +1,119 @@
+package migration
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Files holds the sql migrations so the binary can apply them without the source tree
+//
+//go:embed *.sql
+var Files embed.FS
+
+type Status struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// New returns a migrator for the embedded files, the postgres driver holds an advisory lock
+// while migrating so replicas starting at the same time wait for each other instead of racing
+func New(dbSource string) (*migrate.Migrate, error) {
+	files, err := iofs.New(Files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.NewWithSourceInstance("iofs", files, dbSource)
+}
+
+// Up applies every pending migration, an up to date database is not an error
+func Up(dbSource string) error {
+	m, err := New(dbSource)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	err = m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return err
+}
+
+// Version returns the applied version, 0 when no migration was applied yet
+func Version(m *migrate.Migrate) (uint, bool, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
+
+// Latest returns the version of the newest embedded migration, the version a migrated database is expected to be at
+func Latest() (uint, error) {
+	list, err := List(0)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	return list[len(list)-1].Version, nil
+}
+
+// List returns every embedded migration marked by whether it is applied up to version
+func List(version uint) ([]Status, error) {
+	files, err := iofs.New(Files, ".")
+	if err != nil {
+		return nil, err
+	}
+	defer files.Close()
+
+	var list []Status
+
+	next, err := files.First()
+
+	for err == nil {
+		var name string
+
+		name, err = upName(files, next)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, Status{
+			Version: next,
+			Name:    name,
+			Applied: next <= version,
+		})
+
+		next, err = files.Next(next)
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func upName(files source.Driver, version uint) (string, error) {
+	r, name, err := files.ReadUp(version)
+	if err != nil {
+		return "", err
+	}
+
+	return name, r.Close()
+}
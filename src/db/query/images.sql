@@ -6,4 +6,9 @@ RETURNING id, type, name, url, created_by, created_at;
 -- name: GetImageByUrl :one
 SELECT *
 FROM images
-WHERE url = $1;
\ No newline at end of file
+WHERE url = $1;
+
+-- name: GetImageInfo :one
+SELECT id, type, name, url, created_by, created_at
+FROM images
+WHERE id = $1;
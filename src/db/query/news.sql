@@ -62,3 +62,31 @@ FROM news n
 JOIN images i ON i.id = n.cover_image_id
 JOIN users u ON u.id = n.created_by_id
 WHERE n.id = $1;
+
+-- name: GetNewsWithDetailsBeforeCursor :many
+SELECT 
+    n.id,
+    n.title,
+    n.publish_date,
+    n.description,
+    i.id as image_id,
+    i.url as image_url,
+    i.type as image_type,
+    u.id as user_id,
+    u.name as user_name,
+    u.last_name as user_last_name,
+    u.email as user_email,
+    u.university as user_university,
+    u.department as user_department
+FROM news n
+JOIN images i ON i.id = n.cover_image_id
+JOIN users u ON u.id = n.created_by_id
+WHERE n.id < $1
+ORDER BY n.id DESC
+LIMIT $2;
+
+-- name: CountNewsWithDetails :one
+SELECT COUNT(*)
+FROM news n
+JOIN images i ON i.id = n.cover_image_id
+JOIN users u ON u.id = n.created_by_id;
@@ -0,0 +1,52 @@
+-- name: CreateProjectApplication :one
+INSERT INTO project_applications (position_id,user_id,motivation,status,created_at,updated_at) values ($1,$2,$3,'pending',NOW(),NOW()) RETURNING *;
+
+-- name: GetProjectApplication :one
+SELECT
+    pa.*,
+    pp.project_id
+FROM project_applications pa
+JOIN project_positions pp ON pp.id = pa.position_id
+WHERE pa.id = $1 AND pa.deleted_at IS NULL;
+
+-- name: GetPendingProjectApplication :one
+SELECT * FROM project_applications WHERE position_id = $1 AND user_id = $2 AND status = 'pending' AND deleted_at IS NULL;
+
+-- name: GetPendingProjectApplicationsByProjectId :many
+SELECT
+    pa.id,
+    pa.position_id,
+    pp.role AS position_role,
+    pa.motivation,
+    pa.created_at,
+    u.id AS user_id,
+    u.name,
+    u.last_name,
+    u.email,
+    u.university,
+    u.department
+FROM project_applications pa
+JOIN project_positions pp ON pp.id = pa.position_id
+JOIN users u ON u.id = pa.user_id
+WHERE pp.project_id = $1 AND pa.status = 'pending' AND pa.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY pa.id;
+
+-- name: GetProjectApplicationsByUserId :many
+SELECT
+    pa.*,
+    pp.project_id,
+    pp.role AS position_role
+FROM project_applications pa
+JOIN project_positions pp ON pp.id = pa.position_id
+WHERE pa.user_id = $1 AND pa.deleted_at IS NULL
+ORDER BY pa.id DESC;
+
+-- name: ReviewProjectApplication :one
+UPDATE project_applications SET
+    status = $2,
+    reviewed_by = $3,
+    reviewed_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND deleted_at IS NULL
+RETURNING *;
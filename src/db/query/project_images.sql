@@ -0,0 +1,16 @@
+-- name: CreateProjectImage :one
+INSERT INTO project_images (project_id,image_id,created_at,updated_at) values ($1,$2,NOW(),NOW()) RETURNING *;
+
+-- name: DeleteProjectImage :exec
+UPDATE project_images SET deleted_at = NOW() WHERE project_id = $1 AND image_id = $2 AND deleted_at IS NULL;
+
+-- name: GetProjectImagesByProjectId :many
+SELECT
+    i.id,
+    i.url,
+    i.type,
+    i.name
+FROM project_images pi
+JOIN images i ON i.id = pi.image_id
+WHERE pi.project_id = $1 AND pi.deleted_at IS NULL
+ORDER BY pi.id;
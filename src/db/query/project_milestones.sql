@@ -0,0 +1,27 @@
+-- name: CreateProjectMilestone :one
+INSERT INTO project_milestones (project_id,title,description,due_date,created_at,updated_at) values ($1,$2,$3,$4,NOW(),NOW()) RETURNING *;
+
+-- name: GetProjectMilestone :one
+SELECT pm.* FROM project_milestones pm
+JOIN projects p ON p.id = pm.project_id
+WHERE pm.id = $1 AND pm.deleted_at IS NULL AND p.deleted_at IS NULL;
+
+-- name: GetProjectMilestonesByProjectId :many
+SELECT pm.* FROM project_milestones pm
+JOIN projects p ON p.id = pm.project_id
+WHERE pm.project_id = $1 AND pm.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY pm.due_date, pm.id;
+
+-- name: UpdateProjectMilestone :one
+UPDATE project_milestones SET
+    title = $2,
+    description = $3,
+    due_date = $4,
+    completed_at = $5,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: DeleteProjectMilestone :exec
+UPDATE project_milestones SET deleted_at = NOW() WHERE id = $1;
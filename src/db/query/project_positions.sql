@@ -0,0 +1,46 @@
+-- name: CreateProjectPosition :one
+INSERT INTO project_positions (project_id,role,description,skills,slots,status,created_by,created_at,updated_at) values ($1,$2,$3,$4,$5,'open',$6,NOW(),NOW()) RETURNING *;
+
+-- name: GetProjectPosition :one
+SELECT pp.* FROM project_positions pp
+JOIN projects p ON p.id = pp.project_id
+WHERE pp.id = $1 AND pp.deleted_at IS NULL AND p.deleted_at IS NULL;
+
+-- name: GetProjectPositionsByProjectId :many
+SELECT pp.* FROM project_positions pp
+JOIN projects p ON p.id = pp.project_id
+WHERE pp.project_id = $1 AND pp.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY pp.id;
+
+-- name: GetOpenProjectPositions :many
+SELECT
+    pp.*,
+    p.name AS project_name
+FROM project_positions pp
+JOIN projects p ON p.id = pp.project_id
+WHERE pp.status = 'open' AND pp.deleted_at IS NULL AND p.deleted_at IS NULL AND p.approval_status = 'approved'
+ORDER BY pp.id DESC;
+
+-- name: UpdateProjectPosition :one
+UPDATE project_positions SET
+    role = $2,
+    description = $3,
+    skills = $4,
+    slots = $5,
+    status = $6,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: FillProjectPosition :one
+UPDATE project_positions SET
+    filled_slots = filled_slots + 1,
+    status = CASE WHEN filled_slots + 1 >= slots THEN 'closed' ELSE status END,
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'open' AND filled_slots < slots AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteProjectPosition :exec
+UPDATE project_positions SET deleted_at = NOW() WHERE id = $1;
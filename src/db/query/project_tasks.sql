@@ -0,0 +1,89 @@
+-- name: CreateProjectTask :one
+INSERT INTO project_tasks (
+    project_id,
+    title,
+    description,
+    assignee_id,
+    status,
+    priority,
+    due_date,
+    labels,
+    position,
+    created_by,
+    created_at,
+    updated_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    (SELECT COALESCE(MAX(t.position) + 1, 0) FROM project_tasks t WHERE t.project_id = $1 AND t.status = $5 AND t.deleted_at IS NULL),
+    $9,
+    NOW(),
+    NOW()
+) RETURNING *;
+
+-- name: GetProjectTask :one
+SELECT * FROM project_tasks WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetProjectTasksByProjectId :many
+SELECT
+    t.*,
+    u.name AS assignee_name,
+    u.last_name AS assignee_last_name
+FROM project_tasks t
+LEFT JOIN users u ON u.id = t.assignee_id
+WHERE t.project_id = $1 AND t.deleted_at IS NULL
+ORDER BY t.status, t.position, t.id;
+
+-- name: GetProjectTasksByAssigneeId :many
+SELECT
+    t.*,
+    p.name AS project_name
+FROM project_tasks t
+JOIN projects p ON p.id = t.project_id
+WHERE t.assignee_id = $1 AND t.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY t.due_date NULLS LAST, t.id;
+
+-- name: UpdateProjectTask :one
+UPDATE project_tasks SET
+    title = $2,
+    description = $3,
+    assignee_id = $4,
+    priority = $5,
+    due_date = $6,
+    labels = $7,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: LockProjectTasksByProjectId :many
+SELECT id FROM project_tasks WHERE project_id = $1 AND deleted_at IS NULL FOR UPDATE;
+
+-- name: CountProjectTasksByStatus :one
+SELECT COUNT(*) FROM project_tasks WHERE project_id = $1 AND status = $2 AND deleted_at IS NULL;
+
+-- name: CloseProjectTaskGap :exec
+UPDATE project_tasks SET position = position - 1
+WHERE project_id = $1 AND status = $2 AND position > $3 AND id <> $4 AND deleted_at IS NULL;
+
+-- name: OpenProjectTaskGap :exec
+UPDATE project_tasks SET position = position + 1
+WHERE project_id = $1 AND status = $2 AND position >= $3 AND id <> $4 AND deleted_at IS NULL;
+
+-- name: MoveProjectTask :one
+UPDATE project_tasks SET
+    status = $2,
+    position = $3,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: DeleteProjectTask :exec
+UPDATE project_tasks SET deleted_at = NOW() WHERE id = $1;
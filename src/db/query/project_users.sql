@@ -2,19 +2,47 @@
 INSERT INTO project_users(user_id, project_id,role,created_at,updated_at) values ($1,$2,$3,NOW(),NOW()) RETURNING *;
 
 -- name: DeleteProjectMember :exec
-UPDATE project_users SET deleted_at = NOW() WHERE user_id = $1 AND project_id = $2;
+UPDATE project_users SET deleted_at = NOW() WHERE user_id = $1 AND project_id = $2 AND deleted_at IS NULL;
 
 -- name: DeleteProjectMemberByProjectId :exec
-UPDATE project_users SET deleted_at = NOW() WHERE project_id = $1;
+UPDATE project_users SET deleted_at = NOW() WHERE project_id = $1 AND deleted_at IS NULL;
 
 -- name: DeleteProjectMemberByUserId :exec
-UPDATE project_users SET deleted_at = NOW() WHERE user_id= $1;
+UPDATE project_users SET deleted_at = NOW() WHERE user_id= $1 AND deleted_at IS NULL;
 
 -- name: GetProjectMember :one
 SELECT * FROM project_users WHERE user_id = $1 AND project_id = $2 AND deleted_at IS NULL;
 
 -- name: GetProjectLeadByProjectId :many
-SELECT user_id FROM project_users WHERE project_id = $1 AND role = 'lead' AND  deleted_at IS NULL;
+SELECT pu.user_id FROM project_users pu
+JOIN users u ON u.id = pu.user_id
+WHERE pu.project_id = $1 AND pu.role = 'lead' AND pu.deleted_at IS NULL AND u.deleted_at IS NULL;
 
 -- name: GetProjectsByUserId :many
-SELECT project_id FROM project_users where user_id = $1 AND deleted_at is NULL;
+SELECT p.* FROM project_users pu
+JOIN projects p ON p.id = pu.project_id
+WHERE pu.user_id = $1 AND pu.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY p.id;
+
+-- name: UpdateProjectMemberRole :one
+UPDATE project_users SET role = $3, updated_at = NOW() WHERE project_id = $1 AND user_id = $2 AND deleted_at IS NULL RETURNING *;
+
+-- name: LockProjectLeadsByProjectId :many
+SELECT user_id FROM project_users WHERE project_id = $1 AND role = 'lead' AND deleted_at IS NULL FOR UPDATE;
+
+-- name: LockProjectLeadsOfUser :many
+-- locks the lead rows of every active project the user leads, so no lead can be demoted while the user is deleted
+SELECT pu.project_id, pu.user_id FROM project_users pu
+WHERE pu.role = 'lead' AND pu.deleted_at IS NULL
+    AND pu.project_id IN (
+        SELECT l.project_id FROM project_users l
+        JOIN projects p ON p.id = l.project_id
+        WHERE l.user_id = $1 AND l.role = 'lead' AND l.deleted_at IS NULL AND p.deleted_at IS NULL
+    )
+FOR UPDATE;
+
+-- name: GetProjectLeadsWithDetails :many
+SELECT u.* FROM project_users pu
+JOIN users u ON u.id = pu.user_id
+WHERE pu.project_id = $1 AND pu.role = 'lead' AND pu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY pu.id;
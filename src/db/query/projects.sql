@@ -1,18 +1,34 @@
 -- name: GetAllProjects :many
-SELECT * FROM projects WHERE deleted_at IS NULL LIMIT $1 OFFSET $2;
+SELECT * FROM projects WHERE deleted_at IS NULL AND approval_status = 'approved' ORDER BY id LIMIT $1 OFFSET $2;
+
+-- name: GetAllProjectsAfterCursor :many
+SELECT * FROM projects WHERE deleted_at IS NULL AND approval_status = 'approved' AND id > $1 ORDER BY id LIMIT $2;
+
+-- name: CountProjects :one
+SELECT COUNT(*) FROM projects WHERE deleted_at IS NULL AND approval_status = 'approved';
+
+-- name: GetPendingProjects :many
+SELECT * FROM projects WHERE deleted_at IS NULL AND approval_status = 'pending' ORDER BY id;
 
 -- name: GetProject :one
 SELECT * FROM projects WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: LockProject :one
+SELECT id FROM projects WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE;
+
 -- name: CreateProject :one
 INSERT INTO projects (
     name,
     description,
+    created_by,
+    approval_status,
     created_at,
     updated_at
 ) VALUES (
     $1,
     $2,
+    $3,
+    $4,
     NOW(),
     NOW()
 ) RETURNING *;
@@ -21,13 +37,92 @@ INSERT INTO projects (
 UPDATE projects set
     name = $1,
     description = $2,
+    start_date = $4,
+    end_date = $5,
     updated_at = NOW()
 WHERE
     id = $3
 RETURNING *;
 
+-- name: UpdateProjectStatus :one
+UPDATE projects set
+    status = @status,
+    start_date = @start_date,
+    end_date = @end_date,
+    updated_at = NOW()
+WHERE
+    id = @id AND status = @from_status AND deleted_at IS NULL
+RETURNING *;
+
 -- name: DeleteProject :exec
 UPDATE projects set
     deleted_at = NOW()
 WHERE
-    id = $1;
+    id = $1 AND deleted_at IS NULL;
+
+-- name: ReviewProject :one
+UPDATE projects set
+    approval_status = $2,
+    updated_at = NOW()
+WHERE
+    id = $1 AND approval_status = 'pending' AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateProjectShowcase :one
+UPDATE projects set
+    summary = $2,
+    tech_stack = $3,
+    repository_url = $4,
+    demo_url = $5,
+    cover_image_id = $6,
+    is_published = $7,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING *;
+
+-- name: GetPublishedProjects :many
+SELECT
+    p.id,
+    p.name,
+    p.summary,
+    p.status,
+    p.tech_stack,
+    p.repository_url,
+    p.demo_url,
+    i.url AS cover_image_url
+FROM projects p
+LEFT JOIN images i ON i.id = p.cover_image_id
+WHERE p.is_published AND p.approval_status = 'approved' AND p.deleted_at IS NULL AND p.id > $1
+ORDER BY p.id
+LIMIT $2;
+
+-- name: CountPublishedProjects :one
+SELECT COUNT(*) FROM projects WHERE is_published AND approval_status = 'approved' AND deleted_at IS NULL;
+
+-- name: GetPublishedProject :one
+SELECT
+    p.id,
+    p.name,
+    p.summary,
+    p.status,
+    p.tech_stack,
+    p.repository_url,
+    p.demo_url,
+    i.url AS cover_image_url
+FROM projects p
+LEFT JOIN images i ON i.id = p.cover_image_id
+WHERE p.id = $1 AND p.is_published AND p.approval_status = 'approved' AND p.deleted_at IS NULL;
+
+-- name: GetPublicProjectMembers :many
+SELECT
+    u.name,
+    u.last_name,
+    pu.role
+FROM project_users pu
+JOIN users u ON u.id = pu.user_id
+WHERE pu.project_id = $1 AND pu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY pu.role, pu.id;
+
+-- name: GetProjectApprovalStatus :one
+SELECT approval_status FROM projects WHERE id = $1 AND deleted_at IS NULL;
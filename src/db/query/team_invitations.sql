@@ -0,0 +1,32 @@
+-- name: CreateTeamInvitation :one
+INSERT INTO team_invitations (team_id,email,token_hash,status,invited_by,expires_at,created_at,updated_at) values ($1,$2,$3,'pending',$4,$5,NOW(),NOW()) RETURNING *;
+
+-- name: GetTeamInvitation :one
+SELECT * FROM team_invitations WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetTeamInvitationByTokenHash :one
+SELECT i.* FROM team_invitations i
+JOIN teams t ON t.id = i.team_id
+WHERE i.token_hash = $1 AND i.deleted_at IS NULL AND t.deleted_at IS NULL;
+
+-- name: GetPendingTeamInvitationsByTeamId :many
+SELECT * FROM team_invitations WHERE team_id = $1 AND status = 'pending' AND expires_at > NOW() AND deleted_at IS NULL ORDER BY id;
+
+-- name: AcceptTeamInvitation :one
+UPDATE team_invitations SET
+    status = 'accepted',
+    accepted_by = $2,
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND expires_at > NOW() AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RevokeTeamInvitation :execrows
+UPDATE team_invitations SET
+    status = 'revoked',
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND expires_at > NOW() AND deleted_at IS NULL;
+
+-- name: DeletePendingTeamInvitationsByTeamId :exec
+UPDATE team_invitations SET deleted_at = NOW() WHERE team_id = $1 AND status = 'pending' AND deleted_at IS NULL;
@@ -0,0 +1,39 @@
+-- name: CreateTeamJoinRequest :one
+INSERT INTO team_join_requests (team_id,user_id,message,status,created_at,updated_at) values ($1,$2,$3,'pending',NOW(),NOW()) RETURNING *;
+
+-- name: GetTeamJoinRequest :one
+SELECT r.* FROM team_join_requests r
+JOIN teams t ON t.id = r.team_id
+WHERE r.id = $1 AND r.deleted_at IS NULL AND t.deleted_at IS NULL;
+
+-- name: GetPendingTeamJoinRequest :one
+SELECT * FROM team_join_requests WHERE team_id = $1 AND user_id = $2 AND status = 'pending' AND deleted_at IS NULL;
+
+-- name: GetPendingTeamJoinRequestsByTeamId :many
+SELECT
+    r.id,
+    r.message,
+    r.created_at,
+    u.id AS user_id,
+    u.name,
+    u.last_name,
+    u.email,
+    u.university,
+    u.department
+FROM team_join_requests r
+JOIN users u ON u.id = r.user_id
+WHERE r.team_id = $1 AND r.status = 'pending' AND r.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY r.id;
+
+-- name: ReviewTeamJoinRequest :one
+UPDATE team_join_requests SET
+    status = $2,
+    reviewed_by = $3,
+    reviewed_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeletePendingTeamJoinRequestsByTeamId :exec
+UPDATE team_join_requests SET deleted_at = NOW() WHERE team_id = $1 AND status = 'pending' AND deleted_at IS NULL;
@@ -2,10 +2,49 @@
 INSERT INTO team_projects(team_id, project_id,created_at,updated_at) values ($1,$2,NOW(),NOW()) RETURNING *;
 
 -- name: DeleteTeamProject :exec
-UPDATE team_projects SET deleted_at = NOW() WHERE team_id = $1 AND project_id = $2;
+UPDATE team_projects SET deleted_at = NOW() WHERE team_id = $1 AND project_id = $2 AND deleted_at IS NULL;
+
+-- name: DeleteTeamProjectByTeamId :exec
+UPDATE team_projects SET deleted_at = NOW() WHERE team_id = $1 AND deleted_at IS NULL;
+
+-- name: DeleteTeamProjectByProjectId :exec
+UPDATE team_projects SET deleted_at = NOW() WHERE project_id = $1 AND deleted_at IS NULL;
 
 -- name: GetTeamProjectByTeamId :many
-SELECT project_id FROM team_projects WHERE team_id = $1 AND deleted_at IS NULL;
+SELECT tp.project_id FROM team_projects tp
+JOIN projects p ON p.id = tp.project_id
+WHERE tp.team_id = $1 AND tp.deleted_at IS NULL AND p.deleted_at IS NULL;
 
 -- name: GetProjectTeamByProjectId :many
-SELECT team_id FROM team_projects WHERE project_id = $1 AND deleted_at IS NULL;
+SELECT tp.team_id FROM team_projects tp
+JOIN teams t ON t.id = tp.team_id
+WHERE tp.project_id = $1 AND tp.deleted_at IS NULL AND t.deleted_at IS NULL;
+
+-- name: GetPublishedProjectsByTeamId :many
+SELECT
+    p.id,
+    p.name,
+    p.summary,
+    p.status,
+    p.tech_stack,
+    p.repository_url,
+    p.demo_url,
+    i.url AS cover_image_url
+FROM team_projects tp
+JOIN projects p ON p.id = tp.project_id
+LEFT JOIN images i ON i.id = p.cover_image_id
+WHERE tp.team_id = $1 AND tp.deleted_at IS NULL AND p.deleted_at IS NULL
+    AND p.is_published AND p.approval_status = 'approved'
+ORDER BY p.id;
+
+-- name: GetProjectsByTeamId :many
+SELECT p.* FROM team_projects tp
+JOIN projects p ON p.id = tp.project_id
+WHERE tp.team_id = $1 AND tp.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY p.id;
+
+-- name: GetTeamsByProjectId :many
+SELECT t.* FROM team_projects tp
+JOIN teams t ON t.id = tp.team_id
+WHERE tp.project_id = $1 AND tp.deleted_at IS NULL AND t.deleted_at IS NULL
+ORDER BY t.id;
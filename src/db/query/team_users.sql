@@ -2,19 +2,107 @@
 INSERT INTO team_users (team_id,user_id,role,created_at,updated_at) values ($1,$2,$3,NOW(),NOW()) RETURNING *;
 
 -- name: DeleteTeamMember :exec
-UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1 AND user_id = $2;
+UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1 AND user_id = $2 AND deleted_at IS NULL;
 
 -- name: DeleteTeamMemberByTeamId :exec
-UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1;
+UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1 AND deleted_at IS NULL;
 
 -- name: DeleteTeamMemberByUserId :exec
-UPDATE team_users SET deleted_at = NOW() WHERE user_id = $1;
+UPDATE team_users SET deleted_at = NOW() WHERE user_id = $1 AND deleted_at IS NULL;
 
 -- name: GetTeamMember :one
 SELECT * FROM team_users WHERE team_id = $1 AND user_id = $2 AND deleted_at IS NULL;
 
 -- name: GetTeamLeadByTeamId :many
-SELECT user_id FROM team_users WHERE team_id = $1 AND role = 'lead' AND  deleted_at IS NULL;
+SELECT tu.user_id FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.role = 'lead' AND tu.deleted_at IS NULL AND u.deleted_at IS NULL;
 
 -- name: GetTeamsByUserId :many
-SELECT team_id FROM team_users where user_id = $1 and deleted_at is null;
+SELECT t.* FROM team_users tu
+JOIN teams t ON t.id = tu.team_id
+WHERE tu.user_id = $1 AND tu.deleted_at IS NULL AND t.deleted_at IS NULL
+ORDER BY t.id;
+
+-- name: GetTeamMembersWithDetails :many
+SELECT
+    tu.id,
+    tu.role AS team_role,
+    tu.created_at AS joined_at,
+    u.id AS user_id,
+    u.name,
+    u.last_name,
+    u.email,
+    u.university,
+    u.department
+FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.id > $2 AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.id
+LIMIT $3;
+
+-- name: CountTeamMembers :one
+SELECT COUNT(*) FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.deleted_at IS NULL AND u.deleted_at IS NULL;
+
+-- name: UpdateTeamMemberRole :one
+UPDATE team_users SET role = $3, updated_at = NOW() WHERE team_id = $1 AND user_id = $2 AND deleted_at IS NULL RETURNING *;
+
+-- name: LockTeamLeadsByTeamId :many
+SELECT user_id FROM team_users WHERE team_id = $1 AND role = 'lead' AND deleted_at IS NULL FOR UPDATE;
+
+-- name: IsLeadOfAnyTeam :one
+SELECT EXISTS (
+    SELECT 1 FROM team_users WHERE user_id = $1 AND role = 'lead' AND deleted_at IS NULL
+);
+
+-- name: GetPublicTeamLeads :many
+SELECT
+    u.name,
+    u.last_name
+FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.role = 'lead' AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.id;
+
+-- name: GetAllPublicTeamLeads :many
+SELECT
+    tu.team_id,
+    u.name,
+    u.last_name
+FROM team_users tu
+JOIN teams t ON t.id = tu.team_id
+JOIN users u ON u.id = tu.user_id
+WHERE tu.role = 'lead' AND tu.deleted_at IS NULL AND t.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.team_id, tu.id;
+
+-- name: IsLeadOfTeamOrAncestor :one
+-- division leads inherit the lead permissions of every team below them
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM teams WHERE id = sqlc.arg(team_id)::int AND deleted_at IS NULL
+    UNION
+    SELECT t.id, t.parent_id FROM teams t JOIN ancestors a ON t.id = a.parent_id WHERE t.deleted_at IS NULL
+)
+SELECT EXISTS (
+    SELECT 1 FROM team_users tu
+    JOIN ancestors a ON a.id = tu.team_id
+    WHERE tu.user_id = sqlc.arg(user_id)::int AND tu.role = 'lead' AND tu.deleted_at IS NULL
+);
+
+-- name: LockTeamLeadsOfUser :many
+-- locks the lead rows of every active team the user leads, so no lead can be demoted while the user is deleted
+SELECT tu.team_id, tu.user_id FROM team_users tu
+WHERE tu.role = 'lead' AND tu.deleted_at IS NULL
+    AND tu.team_id IN (
+        SELECT l.team_id FROM team_users l
+        JOIN teams t ON t.id = l.team_id
+        WHERE l.user_id = $1 AND l.role = 'lead' AND l.deleted_at IS NULL AND t.deleted_at IS NULL
+    )
+FOR UPDATE;
+
+-- name: GetTeamLeadsWithDetails :many
+SELECT u.* FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.role = 'lead' AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.id;
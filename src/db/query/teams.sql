@@ -1,5 +1,21 @@
 -- name: GetAllTeams :many
-SELECT * FROM teams WHERE deleted_at IS NULL LIMIT $1 OFFSET $2;
+SELECT t.*, (
+    SELECT COUNT(*) FROM team_users tu
+    JOIN users u ON u.id = tu.user_id
+    WHERE tu.team_id = t.id AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+) AS member_count
+FROM teams t WHERE t.deleted_at IS NULL ORDER BY t.id LIMIT $1 OFFSET $2;
+
+-- name: GetAllTeamsAfterCursor :many
+SELECT t.*, (
+    SELECT COUNT(*) FROM team_users tu
+    JOIN users u ON u.id = tu.user_id
+    WHERE tu.team_id = t.id AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+) AS member_count
+FROM teams t WHERE t.deleted_at IS NULL AND t.id > $1 ORDER BY t.id LIMIT $2;
+
+-- name: CountTeams :one
+SELECT COUNT(*) FROM teams WHERE deleted_at IS NULL;
 
 -- name: GetTeam :one
 SELECT * FROM teams WHERE id = $1 AND deleted_at IS NULL;
@@ -8,11 +24,13 @@ SELECT * FROM teams WHERE id = $1 AND deleted_at IS NULL;
 INSERT INTO teams (
     name,
     description,
+    parent_id,
     created_at,
     updated_at
 ) VALUES (
     $1,
     $2,
+    $3,
     NOW(),
     NOW()
 ) RETURNING *;
@@ -21,6 +39,7 @@ INSERT INTO teams (
 UPDATE teams set
     name = $1,
     description = $2,
+    logo_image_id = $4,
     updated_at = NOW()
 WHERE
     id = $3
@@ -30,4 +49,76 @@ RETURNING *;
 UPDATE teams set
     deleted_at = NOW()
 WHERE
-    id = $1;
+    id = $1 AND deleted_at IS NULL;
+
+-- name: GetPublicTeams :many
+SELECT
+    t.id,
+    t.name,
+    t.description,
+    i.url AS logo_url
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.deleted_at IS NULL AND t.id > $1
+ORDER BY t.id
+LIMIT $2;
+
+-- name: GetPublicTeam :one
+SELECT
+    t.id,
+    t.name,
+    t.description,
+    i.url AS logo_url
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.id = $1 AND t.deleted_at IS NULL;
+
+-- name: GetOrgChartTeams :many
+SELECT
+    t.id,
+    t.name,
+    i.url AS logo_url,
+    (
+        SELECT COUNT(*) FROM team_projects tp
+        JOIN projects p ON p.id = tp.project_id
+        WHERE tp.team_id = t.id AND tp.deleted_at IS NULL AND p.deleted_at IS NULL
+            AND p.is_published AND p.approval_status = 'approved'
+    ) AS project_count
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.deleted_at IS NULL
+ORDER BY t.name, t.id;
+
+-- name: UpdateTeamParent :one
+UPDATE teams set
+    parent_id = $2,
+    updated_at = NOW()
+WHERE
+    id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: LockTeamHierarchy :exec
+SELECT pg_advisory_xact_lock(hashtext('team_hierarchy'));
+
+-- name: IsTeamInSubtree :one
+-- reports whether team_id is root_id itself or one of its descendants
+WITH RECURSIVE subtree AS (
+    SELECT id FROM teams WHERE id = sqlc.arg(root_id)::int
+    UNION
+    SELECT t.id FROM teams t JOIN subtree s ON t.parent_id = s.id
+)
+SELECT EXISTS (
+    SELECT 1 FROM subtree WHERE id = sqlc.arg(team_id)::int
+);
+
+-- name: GetTeamTree :many
+SELECT
+    t.id,
+    t.name,
+    t.description,
+    t.parent_id,
+    i.url AS logo_url
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.deleted_at IS NULL
+ORDER BY t.name, t.id;
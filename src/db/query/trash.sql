@@ -0,0 +1,137 @@
+-- name: GetDeletedTeams :many
+SELECT id, name, description, deleted_at FROM teams
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id
+LIMIT $1;
+
+-- name: GetDeletedProjects :many
+SELECT id, name, description, deleted_at FROM projects
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id
+LIMIT $1;
+
+-- name: GetDeletedUsers :many
+SELECT id, name, last_name, email, deleted_at FROM users
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id
+LIMIT $1;
+
+-- name: GetDeletedTeamMembers :many
+SELECT
+    tu.id,
+    tu.team_id,
+    t.name AS team_name,
+    tu.user_id,
+    u.name,
+    u.last_name,
+    tu.role,
+    tu.deleted_at
+FROM team_users tu
+JOIN teams t ON t.id = tu.team_id
+JOIN users u ON u.id = tu.user_id
+WHERE tu.deleted_at IS NOT NULL
+ORDER BY tu.deleted_at DESC, tu.id
+LIMIT $1;
+
+-- name: GetDeletedProjectMembers :many
+SELECT
+    pu.id,
+    pu.project_id,
+    p.name AS project_name,
+    pu.user_id,
+    u.name,
+    u.last_name,
+    pu.role,
+    pu.deleted_at
+FROM project_users pu
+JOIN projects p ON p.id = pu.project_id
+JOIN users u ON u.id = pu.user_id
+WHERE pu.deleted_at IS NOT NULL
+ORDER BY pu.deleted_at DESC, pu.id
+LIMIT $1;
+
+-- name: GetDeletedTeam :one
+SELECT * FROM teams WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: GetDeletedProject :one
+SELECT * FROM projects WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: GetDeletedUser :one
+SELECT * FROM users WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: RestoreTeam :one
+UPDATE teams SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: RestoreProject :one
+UPDATE projects SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: RestoreUser :one
+UPDATE users SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: RestoreTeamMembers :execrows
+-- memberships are only revived when they were deleted together with or after the restored entity,
+-- the other side is still alive and the row is the latest one of its pair
+UPDATE team_users tu SET deleted_at = NULL, updated_at = NOW()
+WHERE tu.deleted_at >= sqlc.arg(since)::timestamp
+    AND (tu.team_id = sqlc.narg(team_id) OR tu.user_id = sqlc.narg(user_id))
+    AND EXISTS (SELECT 1 FROM teams t WHERE t.id = tu.team_id AND t.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = tu.user_id AND u.deleted_at IS NULL)
+    AND tu.id = (SELECT MAX(d.id) FROM team_users d WHERE d.team_id = tu.team_id AND d.user_id = tu.user_id);
+
+-- name: RestoreProjectMembers :execrows
+-- same rules as RestoreTeamMembers
+UPDATE project_users pu SET deleted_at = NULL, updated_at = NOW()
+WHERE pu.deleted_at >= sqlc.arg(since)::timestamp
+    AND (pu.project_id = sqlc.narg(project_id) OR pu.user_id = sqlc.narg(user_id))
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = pu.project_id AND p.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = pu.user_id AND u.deleted_at IS NULL)
+    AND pu.id = (SELECT MAX(d.id) FROM project_users d WHERE d.project_id = pu.project_id AND d.user_id = pu.user_id);
+
+-- name: RestoreTeamProjects :execrows
+-- same rules as RestoreTeamMembers
+UPDATE team_projects tp SET deleted_at = NULL, updated_at = NOW()
+WHERE tp.deleted_at >= sqlc.arg(since)::timestamp
+    AND (tp.team_id = sqlc.narg(team_id) OR tp.project_id = sqlc.narg(project_id))
+    AND EXISTS (SELECT 1 FROM teams t WHERE t.id = tp.team_id AND t.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = tp.project_id AND p.deleted_at IS NULL)
+    AND tp.id = (SELECT MAX(d.id) FROM team_projects d WHERE d.team_id = tp.team_id AND d.project_id = tp.project_id);
+
+-- name: RestoreTeamMember :one
+UPDATE team_users tu SET deleted_at = NULL, updated_at = NOW()
+WHERE tu.id = $1 AND tu.deleted_at IS NOT NULL
+    AND EXISTS (SELECT 1 FROM teams t WHERE t.id = tu.team_id AND t.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = tu.user_id AND u.deleted_at IS NULL)
+    AND NOT EXISTS (SELECT 1 FROM team_users a WHERE a.team_id = tu.team_id AND a.user_id = tu.user_id AND a.deleted_at IS NULL)
+RETURNING *;
+
+-- name: RestoreProjectMember :one
+UPDATE project_users pu SET deleted_at = NULL, updated_at = NOW()
+WHERE pu.id = $1 AND pu.deleted_at IS NOT NULL
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = pu.project_id AND p.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = pu.user_id AND u.deleted_at IS NULL)
+    AND NOT EXISTS (SELECT 1 FROM project_users a WHERE a.project_id = pu.project_id AND a.user_id = pu.user_id AND a.deleted_at IS NULL)
+RETURNING *;
+
+-- name: PurgeTeamMembers :execrows
+DELETE FROM team_users WHERE deleted_at < sqlc.arg(before)::timestamp;
+
+-- name: PurgeProjectMembers :execrows
+DELETE FROM project_users WHERE deleted_at < sqlc.arg(before)::timestamp;
+
+-- name: PurgeTeamProjects :execrows
+DELETE FROM team_projects WHERE deleted_at < sqlc.arg(before)::timestamp;
+
+-- name: PurgeTeams :execrows
+DELETE FROM teams WHERE deleted_at < sqlc.arg(before)::timestamp;
+
+-- name: PurgeProjects :execrows
+DELETE FROM projects WHERE deleted_at < sqlc.arg(before)::timestamp;
+
+-- name: PurgeUsers :execrows
+-- users who still authored positions, tasks, invitations or announcements stay in the trash
+DELETE FROM users u
+WHERE u.deleted_at < sqlc.arg(before)::timestamp
+    AND NOT EXISTS (SELECT 1 FROM project_positions pp WHERE pp.created_by = u.id)
+    AND NOT EXISTS (SELECT 1 FROM project_tasks pt WHERE pt.created_by = u.id)
+    AND NOT EXISTS (SELECT 1 FROM team_invitations ti WHERE ti.invited_by = u.id)
+    AND NOT EXISTS (SELECT 1 FROM announcements a WHERE a."authorId" = u.id);
@@ -1,5 +1,11 @@
 -- name: GetAllUsers :many
-Select * from users where deleted_at is null LIMIT $1 OFFSET $2;
+Select * from users where deleted_at is null ORDER BY id LIMIT $1 OFFSET $2;
+
+-- name: GetAllUsersAfterCursor :many
+Select * from users where deleted_at is null AND id > $1 ORDER BY id LIMIT $2;
+
+-- name: CountUsers :one
+Select COUNT(*) from users where deleted_at is null;
 
 -- name: GetUserWithNoDetails :one
 Select * from users where id = $1 and deleted_at is null;
@@ -33,14 +39,16 @@ LEFT JOIN
 LEFT JOIN
     projects p on up.project_id = p.id
 WHERE
-    u.email = $1 AND u.deleted_at IS NULL
+    lower(u.email) = lower(@email) AND u.deleted_at IS NULL
 GROUP BY
 		u.id, u.email;
 
 
 -- name: CheckUserIfExistByEmail :one
 SELECT * FROM users
-WHERE email = $1;
+WHERE lower(email) = lower(@email)
+ORDER BY deleted_at IS NULL DESC, id DESC
+LIMIT 1;
 
 -- name: CreateUser :one
 INSERT INTO users (
@@ -89,7 +97,7 @@ returning *;
 UPDATE users SET
     deleted_at = NOW()
 WHERE
-    id = $1;
+    id = $1 AND deleted_at IS NULL;
 
 
 -- name: OverwriteUser :one
@@ -109,3 +117,11 @@ UPDATE users SET
 WHERE
     id = $1
 returning *;
+
+-- name: UpdateUserPassword :one
+UPDATE users SET
+    password = @password,
+    updated_at = NOW()
+WHERE
+    lower(email) = lower(@email) AND deleted_at IS NULL
+returning *;
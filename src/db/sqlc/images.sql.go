@@ -31,6 +31,35 @@ func (q *Queries) GetImageByUrl(ctx context.Context, url string) (Image, error)
 	return i, err
 }
 
+const getImageInfo = `-- name: GetImageInfo :one
+SELECT id, type, name, url, created_by, created_at
+FROM images
+WHERE id = $1
+`
+
+type GetImageInfoRow struct {
+	ID        int32         `json:"id"`
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Url       string        `json:"url"`
+	CreatedBy sql.NullInt32 `json:"created_by"`
+	CreatedAt sql.NullTime  `json:"created_at"`
+}
+
+func (q *Queries) GetImageInfo(ctx context.Context, id int32) (GetImageInfoRow, error) {
+	row := q.db.QueryRowContext(ctx, getImageInfo, id)
+	var i GetImageInfoRow
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Name,
+		&i.Url,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const saveImage = `-- name: SaveImage :one
 INSERT INTO images (type, name, data, url, created_by)
 VALUES ($1, $2, $3, $4, $5)
@@ -38,20 +67,20 @@ RETURNING id, type, name, url, created_by, created_at
 `
 
 type SaveImageParams struct {
-	Type      string `json:"type"`
-	Name      string `json:"name"`
-	Data      []byte `json:"data"`
-	Url       string `json:"url"`
-	CreatedBy int32  `json:"created_by"`
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Data      []byte        `json:"data"`
+	Url       string        `json:"url"`
+	CreatedBy sql.NullInt32 `json:"created_by"`
 }
 
 type SaveImageRow struct {
-	ID        int32        `json:"id"`
-	Type      string       `json:"type"`
-	Name      string       `json:"name"`
-	Url       string       `json:"url"`
-	CreatedBy int32        `json:"created_by"`
-	CreatedAt sql.NullTime `json:"created_at"`
+	ID        int32         `json:"id"`
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Url       string        `json:"url"`
+	CreatedBy sql.NullInt32 `json:"created_by"`
+	CreatedAt sql.NullTime  `json:"created_at"`
 }
 
 func (q *Queries) SaveImage(ctx context.Context, arg SaveImageParams) (SaveImageRow, error) {
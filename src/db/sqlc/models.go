@@ -20,13 +20,13 @@ type Announcement struct {
 }
 
 type Image struct {
-	ID        int32        `json:"id"`
-	Type      string       `json:"type"`
-	Name      string       `json:"name"`
-	Data      []byte       `json:"data"`
-	Url       string       `json:"url"`
-	CreatedBy int32        `json:"created_by"`
-	CreatedAt sql.NullTime `json:"created_at"`
+	ID        int32         `json:"id"`
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Data      []byte        `json:"data"`
+	Url       string        `json:"url"`
+	CreatedBy sql.NullInt32 `json:"created_by"`
+	CreatedAt sql.NullTime  `json:"created_at"`
 }
 
 type News struct {
@@ -39,14 +39,91 @@ type News struct {
 }
 
 type Project struct {
+	ID             int32         `json:"id"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	DeletedAt      sql.NullTime  `json:"deleted_at"`
+	CreatedBy      sql.NullInt32 `json:"created_by"`
+	ApprovalStatus string        `json:"approval_status"`
+	Status         string        `json:"status"`
+	StartDate      sql.NullTime  `json:"start_date"`
+	EndDate        sql.NullTime  `json:"end_date"`
+	Summary        string        `json:"summary"`
+	TechStack      []string      `json:"tech_stack"`
+	RepositoryUrl  string        `json:"repository_url"`
+	DemoUrl        string        `json:"demo_url"`
+	CoverImageID   sql.NullInt32 `json:"cover_image_id"`
+	IsPublished    bool          `json:"is_published"`
+}
+
+type ProjectApplication struct {
+	ID         int32         `json:"id"`
+	PositionID int32         `json:"position_id"`
+	UserID     int32         `json:"user_id"`
+	Motivation string        `json:"motivation"`
+	Status     string        `json:"status"`
+	ReviewedBy sql.NullInt32 `json:"reviewed_by"`
+	ReviewedAt sql.NullTime  `json:"reviewed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DeletedAt  sql.NullTime  `json:"deleted_at"`
+}
+
+type ProjectImage struct {
+	ID        int32        `json:"id"`
+	ProjectID int32        `json:"project_id"`
+	ImageID   int32        `json:"image_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type ProjectMilestone struct {
+	ID          int32        `json:"id"`
+	ProjectID   int32        `json:"project_id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	DueDate     time.Time    `json:"due_date"`
+	CompletedAt sql.NullTime `json:"completed_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+type ProjectPosition struct {
 	ID          int32        `json:"id"`
-	Name        string       `json:"name"`
+	ProjectID   int32        `json:"project_id"`
+	Role        string       `json:"role"`
 	Description string       `json:"description"`
+	Skills      []string     `json:"skills"`
+	Slots       int32        `json:"slots"`
+	FilledSlots int32        `json:"filled_slots"`
+	Status      string       `json:"status"`
+	CreatedBy   int32        `json:"created_by"`
 	CreatedAt   time.Time    `json:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at"`
 	DeletedAt   sql.NullTime `json:"deleted_at"`
 }
 
+type ProjectTask struct {
+	ID          int32         `json:"id"`
+	ProjectID   int32         `json:"project_id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	AssigneeID  sql.NullInt32 `json:"assignee_id"`
+	Status      string        `json:"status"`
+	Priority    string        `json:"priority"`
+	DueDate     sql.NullTime  `json:"due_date"`
+	Labels      []string      `json:"labels"`
+	Position    int32         `json:"position"`
+	CreatedBy   int32         `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+}
+
 type ProjectUser struct {
 	ID        int32        `json:"id"`
 	ProjectID int32        `json:"project_id"`
@@ -58,12 +135,41 @@ type ProjectUser struct {
 }
 
 type Team struct {
-	ID          int32        `json:"id"`
-	Name        string       `json:"name"`
-	Description string       `json:"description"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	DeletedAt   sql.NullTime `json:"deleted_at"`
+	ID          int32         `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	LogoImageID sql.NullInt32 `json:"logo_image_id"`
+	ParentID    sql.NullInt32 `json:"parent_id"`
+}
+
+type TeamInvitation struct {
+	ID         int32         `json:"id"`
+	TeamID     int32         `json:"team_id"`
+	Email      string        `json:"email"`
+	TokenHash  string        `json:"token_hash"`
+	Status     string        `json:"status"`
+	InvitedBy  int32         `json:"invited_by"`
+	AcceptedBy sql.NullInt32 `json:"accepted_by"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DeletedAt  sql.NullTime  `json:"deleted_at"`
+}
+
+type TeamJoinRequest struct {
+	ID         int32         `json:"id"`
+	TeamID     int32         `json:"team_id"`
+	UserID     int32         `json:"user_id"`
+	Message    string        `json:"message"`
+	Status     string        `json:"status"`
+	ReviewedBy sql.NullInt32 `json:"reviewed_by"`
+	ReviewedAt sql.NullTime  `json:"reviewed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DeletedAt  sql.NullTime  `json:"deleted_at"`
 }
 
 type TeamProject struct {
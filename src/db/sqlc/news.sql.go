@@ -11,6 +11,20 @@ import (
 	"time"
 )
 
+const countNewsWithDetails = `-- name: CountNewsWithDetails :one
+SELECT COUNT(*)
+FROM news n
+JOIN images i ON i.id = n.cover_image_id
+JOIN users u ON u.id = n.created_by_id
+`
+
+func (q *Queries) CountNewsWithDetails(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countNewsWithDetails)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createNews = `-- name: CreateNews :one
 INSERT INTO news (title, publish_date, description, cover_image_id, created_by_id)
 VALUES ($1, $2, $3, $4, $5)
@@ -254,6 +268,87 @@ func (q *Queries) GetNewsWithDetails(ctx context.Context, arg GetNewsWithDetails
 	return items, nil
 }
 
+const getNewsWithDetailsBeforeCursor = `-- name: GetNewsWithDetailsBeforeCursor :many
+SELECT 
+    n.id,
+    n.title,
+    n.publish_date,
+    n.description,
+    i.id as image_id,
+    i.url as image_url,
+    i.type as image_type,
+    u.id as user_id,
+    u.name as user_name,
+    u.last_name as user_last_name,
+    u.email as user_email,
+    u.university as user_university,
+    u.department as user_department
+FROM news n
+JOIN images i ON i.id = n.cover_image_id
+JOIN users u ON u.id = n.created_by_id
+WHERE n.id < $1
+ORDER BY n.id DESC
+LIMIT $2
+`
+
+type GetNewsWithDetailsBeforeCursorParams struct {
+	ID    int32 `json:"id"`
+	Limit int32 `json:"limit"`
+}
+
+type GetNewsWithDetailsBeforeCursorRow struct {
+	ID             int32     `json:"id"`
+	Title          string    `json:"title"`
+	PublishDate    time.Time `json:"publish_date"`
+	Description    string    `json:"description"`
+	ImageID        int32     `json:"image_id"`
+	ImageUrl       string    `json:"image_url"`
+	ImageType      string    `json:"image_type"`
+	UserID         int32     `json:"user_id"`
+	UserName       string    `json:"user_name"`
+	UserLastName   string    `json:"user_last_name"`
+	UserEmail      string    `json:"user_email"`
+	UserUniversity string    `json:"user_university"`
+	UserDepartment string    `json:"user_department"`
+}
+
+func (q *Queries) GetNewsWithDetailsBeforeCursor(ctx context.Context, arg GetNewsWithDetailsBeforeCursorParams) ([]GetNewsWithDetailsBeforeCursorRow, error) {
+	rows, err := q.db.QueryContext(ctx, getNewsWithDetailsBeforeCursor, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetNewsWithDetailsBeforeCursorRow{}
+	for rows.Next() {
+		var i GetNewsWithDetailsBeforeCursorRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.PublishDate,
+			&i.Description,
+			&i.ImageID,
+			&i.ImageUrl,
+			&i.ImageType,
+			&i.UserID,
+			&i.UserName,
+			&i.UserLastName,
+			&i.UserEmail,
+			&i.UserUniversity,
+			&i.UserDepartment,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateNews = `-- name: UpdateNews :exec
 UPDATE news
 SET title = $2, publish_date = $3, description = $4, cover_image_id = $5, created_by_id = $6
@@ -0,0 +1,275 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: project_applications.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createProjectApplication = `-- name: CreateProjectApplication :one
+INSERT INTO project_applications (position_id,user_id,motivation,status,created_at,updated_at) values ($1,$2,$3,'pending',NOW(),NOW()) RETURNING id, position_id, user_id, motivation, status, reviewed_by, reviewed_at, created_at, updated_at, deleted_at
+`
+
+type CreateProjectApplicationParams struct {
+	PositionID int32  `json:"position_id"`
+	UserID     int32  `json:"user_id"`
+	Motivation string `json:"motivation"`
+}
+
+func (q *Queries) CreateProjectApplication(ctx context.Context, arg CreateProjectApplicationParams) (ProjectApplication, error) {
+	row := q.db.QueryRowContext(ctx, createProjectApplication, arg.PositionID, arg.UserID, arg.Motivation)
+	var i ProjectApplication
+	err := row.Scan(
+		&i.ID,
+		&i.PositionID,
+		&i.UserID,
+		&i.Motivation,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getPendingProjectApplication = `-- name: GetPendingProjectApplication :one
+SELECT id, position_id, user_id, motivation, status, reviewed_by, reviewed_at, created_at, updated_at, deleted_at FROM project_applications WHERE position_id = $1 AND user_id = $2 AND status = 'pending' AND deleted_at IS NULL
+`
+
+type GetPendingProjectApplicationParams struct {
+	PositionID int32 `json:"position_id"`
+	UserID     int32 `json:"user_id"`
+}
+
+func (q *Queries) GetPendingProjectApplication(ctx context.Context, arg GetPendingProjectApplicationParams) (ProjectApplication, error) {
+	row := q.db.QueryRowContext(ctx, getPendingProjectApplication, arg.PositionID, arg.UserID)
+	var i ProjectApplication
+	err := row.Scan(
+		&i.ID,
+		&i.PositionID,
+		&i.UserID,
+		&i.Motivation,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getPendingProjectApplicationsByProjectId = `-- name: GetPendingProjectApplicationsByProjectId :many
+SELECT
+    pa.id,
+    pa.position_id,
+    pp.role AS position_role,
+    pa.motivation,
+    pa.created_at,
+    u.id AS user_id,
+    u.name,
+    u.last_name,
+    u.email,
+    u.university,
+    u.department
+FROM project_applications pa
+JOIN project_positions pp ON pp.id = pa.position_id
+JOIN users u ON u.id = pa.user_id
+WHERE pp.project_id = $1 AND pa.status = 'pending' AND pa.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY pa.id
+`
+
+type GetPendingProjectApplicationsByProjectIdRow struct {
+	ID           int32     `json:"id"`
+	PositionID   int32     `json:"position_id"`
+	PositionRole string    `json:"position_role"`
+	Motivation   string    `json:"motivation"`
+	CreatedAt    time.Time `json:"created_at"`
+	UserID       int32     `json:"user_id"`
+	Name         string    `json:"name"`
+	LastName     string    `json:"last_name"`
+	Email        string    `json:"email"`
+	University   string    `json:"university"`
+	Department   string    `json:"department"`
+}
+
+func (q *Queries) GetPendingProjectApplicationsByProjectId(ctx context.Context, projectID int32) ([]GetPendingProjectApplicationsByProjectIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingProjectApplicationsByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPendingProjectApplicationsByProjectIdRow{}
+	for rows.Next() {
+		var i GetPendingProjectApplicationsByProjectIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PositionID,
+			&i.PositionRole,
+			&i.Motivation,
+			&i.CreatedAt,
+			&i.UserID,
+			&i.Name,
+			&i.LastName,
+			&i.Email,
+			&i.University,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectApplication = `-- name: GetProjectApplication :one
+SELECT
+    pa.id, pa.position_id, pa.user_id, pa.motivation, pa.status, pa.reviewed_by, pa.reviewed_at, pa.created_at, pa.updated_at, pa.deleted_at,
+    pp.project_id
+FROM project_applications pa
+JOIN project_positions pp ON pp.id = pa.position_id
+WHERE pa.id = $1 AND pa.deleted_at IS NULL
+`
+
+type GetProjectApplicationRow struct {
+	ID         int32         `json:"id"`
+	PositionID int32         `json:"position_id"`
+	UserID     int32         `json:"user_id"`
+	Motivation string        `json:"motivation"`
+	Status     string        `json:"status"`
+	ReviewedBy sql.NullInt32 `json:"reviewed_by"`
+	ReviewedAt sql.NullTime  `json:"reviewed_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	DeletedAt  sql.NullTime  `json:"deleted_at"`
+	ProjectID  int32         `json:"project_id"`
+}
+
+func (q *Queries) GetProjectApplication(ctx context.Context, id int32) (GetProjectApplicationRow, error) {
+	row := q.db.QueryRowContext(ctx, getProjectApplication, id)
+	var i GetProjectApplicationRow
+	err := row.Scan(
+		&i.ID,
+		&i.PositionID,
+		&i.UserID,
+		&i.Motivation,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
+const getProjectApplicationsByUserId = `-- name: GetProjectApplicationsByUserId :many
+SELECT
+    pa.id, pa.position_id, pa.user_id, pa.motivation, pa.status, pa.reviewed_by, pa.reviewed_at, pa.created_at, pa.updated_at, pa.deleted_at,
+    pp.project_id,
+    pp.role AS position_role
+FROM project_applications pa
+JOIN project_positions pp ON pp.id = pa.position_id
+WHERE pa.user_id = $1 AND pa.deleted_at IS NULL
+ORDER BY pa.id DESC
+`
+
+type GetProjectApplicationsByUserIdRow struct {
+	ID           int32         `json:"id"`
+	PositionID   int32         `json:"position_id"`
+	UserID       int32         `json:"user_id"`
+	Motivation   string        `json:"motivation"`
+	Status       string        `json:"status"`
+	ReviewedBy   sql.NullInt32 `json:"reviewed_by"`
+	ReviewedAt   sql.NullTime  `json:"reviewed_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	DeletedAt    sql.NullTime  `json:"deleted_at"`
+	ProjectID    int32         `json:"project_id"`
+	PositionRole string        `json:"position_role"`
+}
+
+func (q *Queries) GetProjectApplicationsByUserId(ctx context.Context, userID int32) ([]GetProjectApplicationsByUserIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectApplicationsByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProjectApplicationsByUserIdRow{}
+	for rows.Next() {
+		var i GetProjectApplicationsByUserIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PositionID,
+			&i.UserID,
+			&i.Motivation,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ProjectID,
+			&i.PositionRole,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewProjectApplication = `-- name: ReviewProjectApplication :one
+UPDATE project_applications SET
+    status = $2,
+    reviewed_by = $3,
+    reviewed_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND deleted_at IS NULL
+RETURNING id, position_id, user_id, motivation, status, reviewed_by, reviewed_at, created_at, updated_at, deleted_at
+`
+
+type ReviewProjectApplicationParams struct {
+	ID         int32         `json:"id"`
+	Status     string        `json:"status"`
+	ReviewedBy sql.NullInt32 `json:"reviewed_by"`
+}
+
+func (q *Queries) ReviewProjectApplication(ctx context.Context, arg ReviewProjectApplicationParams) (ProjectApplication, error) {
+	row := q.db.QueryRowContext(ctx, reviewProjectApplication, arg.ID, arg.Status, arg.ReviewedBy)
+	var i ProjectApplication
+	err := row.Scan(
+		&i.ID,
+		&i.PositionID,
+		&i.UserID,
+		&i.Motivation,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,94 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: project_images.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const createProjectImage = `-- name: CreateProjectImage :one
+INSERT INTO project_images (project_id,image_id,created_at,updated_at) values ($1,$2,NOW(),NOW()) RETURNING id, project_id, image_id, created_at, updated_at, deleted_at
+`
+
+type CreateProjectImageParams struct {
+	ProjectID int32 `json:"project_id"`
+	ImageID   int32 `json:"image_id"`
+}
+
+func (q *Queries) CreateProjectImage(ctx context.Context, arg CreateProjectImageParams) (ProjectImage, error) {
+	row := q.db.QueryRowContext(ctx, createProjectImage, arg.ProjectID, arg.ImageID)
+	var i ProjectImage
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.ImageID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteProjectImage = `-- name: DeleteProjectImage :exec
+UPDATE project_images SET deleted_at = NOW() WHERE project_id = $1 AND image_id = $2 AND deleted_at IS NULL
+`
+
+type DeleteProjectImageParams struct {
+	ProjectID int32 `json:"project_id"`
+	ImageID   int32 `json:"image_id"`
+}
+
+func (q *Queries) DeleteProjectImage(ctx context.Context, arg DeleteProjectImageParams) error {
+	_, err := q.db.ExecContext(ctx, deleteProjectImage, arg.ProjectID, arg.ImageID)
+	return err
+}
+
+const getProjectImagesByProjectId = `-- name: GetProjectImagesByProjectId :many
+SELECT
+    i.id,
+    i.url,
+    i.type,
+    i.name
+FROM project_images pi
+JOIN images i ON i.id = pi.image_id
+WHERE pi.project_id = $1 AND pi.deleted_at IS NULL
+ORDER BY pi.id
+`
+
+type GetProjectImagesByProjectIdRow struct {
+	ID   int32  `json:"id"`
+	Url  string `json:"url"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) GetProjectImagesByProjectId(ctx context.Context, projectID int32) ([]GetProjectImagesByProjectIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectImagesByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProjectImagesByProjectIdRow{}
+	for rows.Next() {
+		var i GetProjectImagesByProjectIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Type,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
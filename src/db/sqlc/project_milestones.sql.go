@@ -0,0 +1,160 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: project_milestones.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createProjectMilestone = `-- name: CreateProjectMilestone :one
+INSERT INTO project_milestones (project_id,title,description,due_date,created_at,updated_at) values ($1,$2,$3,$4,NOW(),NOW()) RETURNING id, project_id, title, description, due_date, completed_at, created_at, updated_at, deleted_at
+`
+
+type CreateProjectMilestoneParams struct {
+	ProjectID   int32     `json:"project_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	DueDate     time.Time `json:"due_date"`
+}
+
+func (q *Queries) CreateProjectMilestone(ctx context.Context, arg CreateProjectMilestoneParams) (ProjectMilestone, error) {
+	row := q.db.QueryRowContext(ctx, createProjectMilestone,
+		arg.ProjectID,
+		arg.Title,
+		arg.Description,
+		arg.DueDate,
+	)
+	var i ProjectMilestone
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Title,
+		&i.Description,
+		&i.DueDate,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteProjectMilestone = `-- name: DeleteProjectMilestone :exec
+UPDATE project_milestones SET deleted_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) DeleteProjectMilestone(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteProjectMilestone, id)
+	return err
+}
+
+const getProjectMilestone = `-- name: GetProjectMilestone :one
+SELECT pm.id, pm.project_id, pm.title, pm.description, pm.due_date, pm.completed_at, pm.created_at, pm.updated_at, pm.deleted_at FROM project_milestones pm
+JOIN projects p ON p.id = pm.project_id
+WHERE pm.id = $1 AND pm.deleted_at IS NULL AND p.deleted_at IS NULL
+`
+
+func (q *Queries) GetProjectMilestone(ctx context.Context, id int32) (ProjectMilestone, error) {
+	row := q.db.QueryRowContext(ctx, getProjectMilestone, id)
+	var i ProjectMilestone
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Title,
+		&i.Description,
+		&i.DueDate,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProjectMilestonesByProjectId = `-- name: GetProjectMilestonesByProjectId :many
+SELECT pm.id, pm.project_id, pm.title, pm.description, pm.due_date, pm.completed_at, pm.created_at, pm.updated_at, pm.deleted_at FROM project_milestones pm
+JOIN projects p ON p.id = pm.project_id
+WHERE pm.project_id = $1 AND pm.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY pm.due_date, pm.id
+`
+
+func (q *Queries) GetProjectMilestonesByProjectId(ctx context.Context, projectID int32) ([]ProjectMilestone, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectMilestonesByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectMilestone{}
+	for rows.Next() {
+		var i ProjectMilestone
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Title,
+			&i.Description,
+			&i.DueDate,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProjectMilestone = `-- name: UpdateProjectMilestone :one
+UPDATE project_milestones SET
+    title = $2,
+    description = $3,
+    due_date = $4,
+    completed_at = $5,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING id, project_id, title, description, due_date, completed_at, created_at, updated_at, deleted_at
+`
+
+type UpdateProjectMilestoneParams struct {
+	ID          int32        `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	DueDate     time.Time    `json:"due_date"`
+	CompletedAt sql.NullTime `json:"completed_at"`
+}
+
+func (q *Queries) UpdateProjectMilestone(ctx context.Context, arg UpdateProjectMilestoneParams) (ProjectMilestone, error) {
+	row := q.db.QueryRowContext(ctx, updateProjectMilestone,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.DueDate,
+		arg.CompletedAt,
+	)
+	var i ProjectMilestone
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Title,
+		&i.Description,
+		&i.DueDate,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
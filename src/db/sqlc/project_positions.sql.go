@@ -0,0 +1,274 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: project_positions.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const createProjectPosition = `-- name: CreateProjectPosition :one
+INSERT INTO project_positions (project_id,role,description,skills,slots,status,created_by,created_at,updated_at) values ($1,$2,$3,$4,$5,'open',$6,NOW(),NOW()) RETURNING id, project_id, role, description, skills, slots, filled_slots, status, created_by, created_at, updated_at, deleted_at
+`
+
+type CreateProjectPositionParams struct {
+	ProjectID   int32    `json:"project_id"`
+	Role        string   `json:"role"`
+	Description string   `json:"description"`
+	Skills      []string `json:"skills"`
+	Slots       int32    `json:"slots"`
+	CreatedBy   int32    `json:"created_by"`
+}
+
+func (q *Queries) CreateProjectPosition(ctx context.Context, arg CreateProjectPositionParams) (ProjectPosition, error) {
+	row := q.db.QueryRowContext(ctx, createProjectPosition,
+		arg.ProjectID,
+		arg.Role,
+		arg.Description,
+		pq.Array(arg.Skills),
+		arg.Slots,
+		arg.CreatedBy,
+	)
+	var i ProjectPosition
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Role,
+		&i.Description,
+		pq.Array(&i.Skills),
+		&i.Slots,
+		&i.FilledSlots,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteProjectPosition = `-- name: DeleteProjectPosition :exec
+UPDATE project_positions SET deleted_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) DeleteProjectPosition(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteProjectPosition, id)
+	return err
+}
+
+const fillProjectPosition = `-- name: FillProjectPosition :one
+UPDATE project_positions SET
+    filled_slots = filled_slots + 1,
+    status = CASE WHEN filled_slots + 1 >= slots THEN 'closed' ELSE status END,
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'open' AND filled_slots < slots AND deleted_at IS NULL
+RETURNING id, project_id, role, description, skills, slots, filled_slots, status, created_by, created_at, updated_at, deleted_at
+`
+
+func (q *Queries) FillProjectPosition(ctx context.Context, id int32) (ProjectPosition, error) {
+	row := q.db.QueryRowContext(ctx, fillProjectPosition, id)
+	var i ProjectPosition
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Role,
+		&i.Description,
+		pq.Array(&i.Skills),
+		&i.Slots,
+		&i.FilledSlots,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getOpenProjectPositions = `-- name: GetOpenProjectPositions :many
+SELECT
+    pp.id, pp.project_id, pp.role, pp.description, pp.skills, pp.slots, pp.filled_slots, pp.status, pp.created_by, pp.created_at, pp.updated_at, pp.deleted_at,
+    p.name AS project_name
+FROM project_positions pp
+JOIN projects p ON p.id = pp.project_id
+WHERE pp.status = 'open' AND pp.deleted_at IS NULL AND p.deleted_at IS NULL AND p.approval_status = 'approved'
+ORDER BY pp.id DESC
+`
+
+type GetOpenProjectPositionsRow struct {
+	ID          int32        `json:"id"`
+	ProjectID   int32        `json:"project_id"`
+	Role        string       `json:"role"`
+	Description string       `json:"description"`
+	Skills      []string     `json:"skills"`
+	Slots       int32        `json:"slots"`
+	FilledSlots int32        `json:"filled_slots"`
+	Status      string       `json:"status"`
+	CreatedBy   int32        `json:"created_by"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+	ProjectName string       `json:"project_name"`
+}
+
+func (q *Queries) GetOpenProjectPositions(ctx context.Context) ([]GetOpenProjectPositionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOpenProjectPositions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOpenProjectPositionsRow{}
+	for rows.Next() {
+		var i GetOpenProjectPositionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Role,
+			&i.Description,
+			pq.Array(&i.Skills),
+			&i.Slots,
+			&i.FilledSlots,
+			&i.Status,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ProjectName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectPosition = `-- name: GetProjectPosition :one
+SELECT pp.id, pp.project_id, pp.role, pp.description, pp.skills, pp.slots, pp.filled_slots, pp.status, pp.created_by, pp.created_at, pp.updated_at, pp.deleted_at FROM project_positions pp
+JOIN projects p ON p.id = pp.project_id
+WHERE pp.id = $1 AND pp.deleted_at IS NULL AND p.deleted_at IS NULL
+`
+
+func (q *Queries) GetProjectPosition(ctx context.Context, id int32) (ProjectPosition, error) {
+	row := q.db.QueryRowContext(ctx, getProjectPosition, id)
+	var i ProjectPosition
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Role,
+		&i.Description,
+		pq.Array(&i.Skills),
+		&i.Slots,
+		&i.FilledSlots,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProjectPositionsByProjectId = `-- name: GetProjectPositionsByProjectId :many
+SELECT pp.id, pp.project_id, pp.role, pp.description, pp.skills, pp.slots, pp.filled_slots, pp.status, pp.created_by, pp.created_at, pp.updated_at, pp.deleted_at FROM project_positions pp
+JOIN projects p ON p.id = pp.project_id
+WHERE pp.project_id = $1 AND pp.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY pp.id
+`
+
+func (q *Queries) GetProjectPositionsByProjectId(ctx context.Context, projectID int32) ([]ProjectPosition, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectPositionsByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectPosition{}
+	for rows.Next() {
+		var i ProjectPosition
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Role,
+			&i.Description,
+			pq.Array(&i.Skills),
+			&i.Slots,
+			&i.FilledSlots,
+			&i.Status,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProjectPosition = `-- name: UpdateProjectPosition :one
+UPDATE project_positions SET
+    role = $2,
+    description = $3,
+    skills = $4,
+    slots = $5,
+    status = $6,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING id, project_id, role, description, skills, slots, filled_slots, status, created_by, created_at, updated_at, deleted_at
+`
+
+type UpdateProjectPositionParams struct {
+	ID          int32    `json:"id"`
+	Role        string   `json:"role"`
+	Description string   `json:"description"`
+	Skills      []string `json:"skills"`
+	Slots       int32    `json:"slots"`
+	Status      string   `json:"status"`
+}
+
+func (q *Queries) UpdateProjectPosition(ctx context.Context, arg UpdateProjectPositionParams) (ProjectPosition, error) {
+	row := q.db.QueryRowContext(ctx, updateProjectPosition,
+		arg.ID,
+		arg.Role,
+		arg.Description,
+		pq.Array(arg.Skills),
+		arg.Slots,
+		arg.Status,
+	)
+	var i ProjectPosition
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Role,
+		&i.Description,
+		pq.Array(&i.Skills),
+		&i.Slots,
+		&i.FilledSlots,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
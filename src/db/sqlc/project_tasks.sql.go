@@ -0,0 +1,439 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: project_tasks.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const closeProjectTaskGap = `-- name: CloseProjectTaskGap :exec
+UPDATE project_tasks SET position = position - 1
+WHERE project_id = $1 AND status = $2 AND position > $3 AND id <> $4 AND deleted_at IS NULL
+`
+
+type CloseProjectTaskGapParams struct {
+	ProjectID int32  `json:"project_id"`
+	Status    string `json:"status"`
+	Position  int32  `json:"position"`
+	ID        int32  `json:"id"`
+}
+
+func (q *Queries) CloseProjectTaskGap(ctx context.Context, arg CloseProjectTaskGapParams) error {
+	_, err := q.db.ExecContext(ctx, closeProjectTaskGap,
+		arg.ProjectID,
+		arg.Status,
+		arg.Position,
+		arg.ID,
+	)
+	return err
+}
+
+const countProjectTasksByStatus = `-- name: CountProjectTasksByStatus :one
+SELECT COUNT(*) FROM project_tasks WHERE project_id = $1 AND status = $2 AND deleted_at IS NULL
+`
+
+type CountProjectTasksByStatusParams struct {
+	ProjectID int32  `json:"project_id"`
+	Status    string `json:"status"`
+}
+
+func (q *Queries) CountProjectTasksByStatus(ctx context.Context, arg CountProjectTasksByStatusParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProjectTasksByStatus, arg.ProjectID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createProjectTask = `-- name: CreateProjectTask :one
+INSERT INTO project_tasks (
+    project_id,
+    title,
+    description,
+    assignee_id,
+    status,
+    priority,
+    due_date,
+    labels,
+    position,
+    created_by,
+    created_at,
+    updated_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    (SELECT COALESCE(MAX(t.position) + 1, 0) FROM project_tasks t WHERE t.project_id = $1 AND t.status = $5 AND t.deleted_at IS NULL),
+    $9,
+    NOW(),
+    NOW()
+) RETURNING id, project_id, title, description, assignee_id, status, priority, due_date, labels, position, created_by, created_at, updated_at, deleted_at
+`
+
+type CreateProjectTaskParams struct {
+	ProjectID   int32         `json:"project_id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	AssigneeID  sql.NullInt32 `json:"assignee_id"`
+	Status      string        `json:"status"`
+	Priority    string        `json:"priority"`
+	DueDate     sql.NullTime  `json:"due_date"`
+	Labels      []string      `json:"labels"`
+	CreatedBy   int32         `json:"created_by"`
+}
+
+func (q *Queries) CreateProjectTask(ctx context.Context, arg CreateProjectTaskParams) (ProjectTask, error) {
+	row := q.db.QueryRowContext(ctx, createProjectTask,
+		arg.ProjectID,
+		arg.Title,
+		arg.Description,
+		arg.AssigneeID,
+		arg.Status,
+		arg.Priority,
+		arg.DueDate,
+		pq.Array(arg.Labels),
+		arg.CreatedBy,
+	)
+	var i ProjectTask
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Title,
+		&i.Description,
+		&i.AssigneeID,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		pq.Array(&i.Labels),
+		&i.Position,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteProjectTask = `-- name: DeleteProjectTask :exec
+UPDATE project_tasks SET deleted_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) DeleteProjectTask(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteProjectTask, id)
+	return err
+}
+
+const getProjectTask = `-- name: GetProjectTask :one
+SELECT id, project_id, title, description, assignee_id, status, priority, due_date, labels, position, created_by, created_at, updated_at, deleted_at FROM project_tasks WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetProjectTask(ctx context.Context, id int32) (ProjectTask, error) {
+	row := q.db.QueryRowContext(ctx, getProjectTask, id)
+	var i ProjectTask
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Title,
+		&i.Description,
+		&i.AssigneeID,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		pq.Array(&i.Labels),
+		&i.Position,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProjectTasksByAssigneeId = `-- name: GetProjectTasksByAssigneeId :many
+SELECT
+    t.id, t.project_id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.labels, t.position, t.created_by, t.created_at, t.updated_at, t.deleted_at,
+    p.name AS project_name
+FROM project_tasks t
+JOIN projects p ON p.id = t.project_id
+WHERE t.assignee_id = $1 AND t.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY t.due_date NULLS LAST, t.id
+`
+
+type GetProjectTasksByAssigneeIdRow struct {
+	ID          int32         `json:"id"`
+	ProjectID   int32         `json:"project_id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	AssigneeID  sql.NullInt32 `json:"assignee_id"`
+	Status      string        `json:"status"`
+	Priority    string        `json:"priority"`
+	DueDate     sql.NullTime  `json:"due_date"`
+	Labels      []string      `json:"labels"`
+	Position    int32         `json:"position"`
+	CreatedBy   int32         `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	ProjectName string        `json:"project_name"`
+}
+
+func (q *Queries) GetProjectTasksByAssigneeId(ctx context.Context, assigneeID sql.NullInt32) ([]GetProjectTasksByAssigneeIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectTasksByAssigneeId, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProjectTasksByAssigneeIdRow{}
+	for rows.Next() {
+		var i GetProjectTasksByAssigneeIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Title,
+			&i.Description,
+			&i.AssigneeID,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			pq.Array(&i.Labels),
+			&i.Position,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ProjectName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectTasksByProjectId = `-- name: GetProjectTasksByProjectId :many
+SELECT
+    t.id, t.project_id, t.title, t.description, t.assignee_id, t.status, t.priority, t.due_date, t.labels, t.position, t.created_by, t.created_at, t.updated_at, t.deleted_at,
+    u.name AS assignee_name,
+    u.last_name AS assignee_last_name
+FROM project_tasks t
+LEFT JOIN users u ON u.id = t.assignee_id
+WHERE t.project_id = $1 AND t.deleted_at IS NULL
+ORDER BY t.status, t.position, t.id
+`
+
+type GetProjectTasksByProjectIdRow struct {
+	ID               int32          `json:"id"`
+	ProjectID        int32          `json:"project_id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	AssigneeID       sql.NullInt32  `json:"assignee_id"`
+	Status           string         `json:"status"`
+	Priority         string         `json:"priority"`
+	DueDate          sql.NullTime   `json:"due_date"`
+	Labels           []string       `json:"labels"`
+	Position         int32          `json:"position"`
+	CreatedBy        int32          `json:"created_by"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	AssigneeName     sql.NullString `json:"assignee_name"`
+	AssigneeLastName sql.NullString `json:"assignee_last_name"`
+}
+
+func (q *Queries) GetProjectTasksByProjectId(ctx context.Context, projectID int32) ([]GetProjectTasksByProjectIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectTasksByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProjectTasksByProjectIdRow{}
+	for rows.Next() {
+		var i GetProjectTasksByProjectIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Title,
+			&i.Description,
+			&i.AssigneeID,
+			&i.Status,
+			&i.Priority,
+			&i.DueDate,
+			pq.Array(&i.Labels),
+			&i.Position,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AssigneeName,
+			&i.AssigneeLastName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockProjectTasksByProjectId = `-- name: LockProjectTasksByProjectId :many
+SELECT id FROM project_tasks WHERE project_id = $1 AND deleted_at IS NULL FOR UPDATE
+`
+
+func (q *Queries) LockProjectTasksByProjectId(ctx context.Context, projectID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, lockProjectTasksByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveProjectTask = `-- name: MoveProjectTask :one
+UPDATE project_tasks SET
+    status = $2,
+    position = $3,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING id, project_id, title, description, assignee_id, status, priority, due_date, labels, position, created_by, created_at, updated_at, deleted_at
+`
+
+type MoveProjectTaskParams struct {
+	ID       int32  `json:"id"`
+	Status   string `json:"status"`
+	Position int32  `json:"position"`
+}
+
+func (q *Queries) MoveProjectTask(ctx context.Context, arg MoveProjectTaskParams) (ProjectTask, error) {
+	row := q.db.QueryRowContext(ctx, moveProjectTask, arg.ID, arg.Status, arg.Position)
+	var i ProjectTask
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Title,
+		&i.Description,
+		&i.AssigneeID,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		pq.Array(&i.Labels),
+		&i.Position,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const openProjectTaskGap = `-- name: OpenProjectTaskGap :exec
+UPDATE project_tasks SET position = position + 1
+WHERE project_id = $1 AND status = $2 AND position >= $3 AND id <> $4 AND deleted_at IS NULL
+`
+
+type OpenProjectTaskGapParams struct {
+	ProjectID int32  `json:"project_id"`
+	Status    string `json:"status"`
+	Position  int32  `json:"position"`
+	ID        int32  `json:"id"`
+}
+
+func (q *Queries) OpenProjectTaskGap(ctx context.Context, arg OpenProjectTaskGapParams) error {
+	_, err := q.db.ExecContext(ctx, openProjectTaskGap,
+		arg.ProjectID,
+		arg.Status,
+		arg.Position,
+		arg.ID,
+	)
+	return err
+}
+
+const updateProjectTask = `-- name: UpdateProjectTask :one
+UPDATE project_tasks SET
+    title = $2,
+    description = $3,
+    assignee_id = $4,
+    priority = $5,
+    due_date = $6,
+    labels = $7,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING id, project_id, title, description, assignee_id, status, priority, due_date, labels, position, created_by, created_at, updated_at, deleted_at
+`
+
+type UpdateProjectTaskParams struct {
+	ID          int32         `json:"id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	AssigneeID  sql.NullInt32 `json:"assignee_id"`
+	Priority    string        `json:"priority"`
+	DueDate     sql.NullTime  `json:"due_date"`
+	Labels      []string      `json:"labels"`
+}
+
+func (q *Queries) UpdateProjectTask(ctx context.Context, arg UpdateProjectTaskParams) (ProjectTask, error) {
+	row := q.db.QueryRowContext(ctx, updateProjectTask,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.AssigneeID,
+		arg.Priority,
+		arg.DueDate,
+		pq.Array(arg.Labels),
+	)
+	var i ProjectTask
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Title,
+		&i.Description,
+		&i.AssigneeID,
+		&i.Status,
+		&i.Priority,
+		&i.DueDate,
+		pq.Array(&i.Labels),
+		&i.Position,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
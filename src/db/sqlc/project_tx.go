@@ -0,0 +1,251 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrPositionFilled is returned when an application is accepted for a position without free slots
+var ErrPositionFilled = errors.New("position has no open slots")
+
+type AcceptProjectApplicationTxParams struct {
+	ApplicationID int32 `json:"application_id"`
+	ReviewerID    int32 `json:"reviewer_id"`
+}
+
+type AcceptProjectApplicationTxResult struct {
+	Application ProjectApplication `json:"application"`
+	Position    ProjectPosition    `json:"position"`
+	Member      ProjectUser        `json:"member"`
+}
+
+// AcceptProjectApplicationTx accepts a pending application, takes one slot of its position
+// and adds the applicant to the project.
+// It returns sql.ErrNoRows if the application was already reviewed.
+func (store *Store) AcceptProjectApplicationTx(ctx context.Context, arg AcceptProjectApplicationTxParams) (AcceptProjectApplicationTxResult, error) {
+	var result AcceptProjectApplicationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Application, err = q.ReviewProjectApplication(ctx, ReviewProjectApplicationParams{
+			ID:         arg.ApplicationID,
+			Status:     "accepted",
+			ReviewedBy: sql.NullInt32{Int32: arg.ReviewerID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Position, err = q.FillProjectPosition(ctx, result.Application.PositionID)
+		if err == sql.ErrNoRows {
+			return ErrPositionFilled
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Member, err = addProjectMemberIfMissing(ctx, q, result.Position.ProjectID, result.Application.UserID)
+		return err
+	})
+
+	return result, err
+}
+
+// addProjectMemberIfMissing keeps the existing membership, so a lead is never demoted by joining again
+func addProjectMemberIfMissing(ctx context.Context, q *Queries, projectID int32, userID int32) (ProjectUser, error) {
+	member, err := q.GetProjectMember(ctx, GetProjectMemberParams{
+		ProjectID: projectID,
+		UserID:    userID,
+	})
+	if err != sql.ErrNoRows {
+		return member, err
+	}
+
+	return q.CreateProjectMember(ctx, CreateProjectMemberParams{
+		ProjectID: projectID,
+		UserID:    userID,
+		Role:      "member",
+	})
+}
+
+type ChangeProjectMemberRoleTxParams struct {
+	ProjectID int32  `json:"project_id"`
+	UserID    int32  `json:"user_id"`
+	Role      string `json:"role"`
+}
+
+// ChangeProjectMemberRoleTx changes the role of a project member.
+// The leads of the project are locked, so concurrent demotions can not leave the project without a lead.
+func (store *Store) ChangeProjectMemberRoleTx(ctx context.Context, arg ChangeProjectMemberRoleTxParams) (ProjectUser, error) {
+	var result ProjectUser
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		leads, err := q.LockProjectLeadsByProjectId(ctx, arg.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Role != "lead" && isOnlyLead(leads, arg.UserID) {
+			return ErrLastLead
+		}
+
+		result, err = q.UpdateProjectMemberRole(ctx, UpdateProjectMemberRoleParams(arg))
+		if err == sql.ErrNoRows {
+			return ErrNotMember
+		}
+		return err
+	})
+
+	return result, err
+}
+
+type TransferProjectLeadershipTxParams struct {
+	ProjectID  int32 `json:"project_id"`
+	FromUserID int32 `json:"from_user_id"`
+	ToUserID   int32 `json:"to_user_id"`
+}
+
+type TransferProjectLeadershipTxResult struct {
+	PreviousLead ProjectUser `json:"previous_lead"`
+	NewLead      ProjectUser `json:"new_lead"`
+}
+
+// TransferProjectLeadershipTx promotes a member to lead and demotes the previous lead in one step
+func (store *Store) TransferProjectLeadershipTx(ctx context.Context, arg TransferProjectLeadershipTxParams) (TransferProjectLeadershipTxResult, error) {
+	var result TransferProjectLeadershipTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		leads, err := q.LockProjectLeadsByProjectId(ctx, arg.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		if !containsUser(leads, arg.FromUserID) {
+			return ErrNotLead
+		}
+
+		result.NewLead, err = q.UpdateProjectMemberRole(ctx, UpdateProjectMemberRoleParams{
+			ProjectID: arg.ProjectID,
+			UserID:    arg.ToUserID,
+			Role:      "lead",
+		})
+		if err == sql.ErrNoRows {
+			return ErrNotMember
+		}
+		if err != nil {
+			return err
+		}
+
+		result.PreviousLead, err = q.UpdateProjectMemberRole(ctx, UpdateProjectMemberRoleParams{
+			ProjectID: arg.ProjectID,
+			UserID:    arg.FromUserID,
+			Role:      "member",
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// RemoveProjectMemberTx removes a member from the project unless they are its only lead
+func (store *Store) RemoveProjectMemberTx(ctx context.Context, arg DeleteProjectMemberParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		leads, err := q.LockProjectLeadsByProjectId(ctx, arg.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		if isOnlyLead(leads, arg.UserID) {
+			return ErrLastLead
+		}
+
+		return q.DeleteProjectMember(ctx, arg)
+	})
+}
+
+type CreateProjectTxParams struct {
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	CreatorID      int32  `json:"creator_id"`
+	ApprovalStatus string `json:"approval_status"`
+	// TeamID links the new project to a team when it is not zero
+	TeamID int32 `json:"team_id"`
+}
+
+type CreateProjectTxResult struct {
+	Project     Project      `json:"project"`
+	Lead        ProjectUser  `json:"lead"`
+	TeamProject *TeamProject `json:"team_project,omitempty"`
+}
+
+// CreateProjectTx creates a project with its creator as the first lead and optionally links it to a team
+func (store *Store) CreateProjectTx(ctx context.Context, arg CreateProjectTxParams) (CreateProjectTxResult, error) {
+	var result CreateProjectTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Project, err = q.CreateProject(ctx, CreateProjectParams{
+			Name:           arg.Name,
+			Description:    arg.Description,
+			CreatedBy:      sql.NullInt32{Int32: arg.CreatorID, Valid: true},
+			ApprovalStatus: arg.ApprovalStatus,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Lead, err = q.CreateProjectMember(ctx, CreateProjectMemberParams{
+			ProjectID: result.Project.ID,
+			UserID:    arg.CreatorID,
+			Role:      "lead",
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.TeamID == 0 {
+			return nil
+		}
+
+		teamProject, err := q.CreateTeamProject(ctx, CreateTeamProjectParams{
+			TeamID:    arg.TeamID,
+			ProjectID: result.Project.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.TeamProject = &teamProject
+		return nil
+	})
+
+	return result, err
+}
+
+// DeleteProjectTx soft-deletes a project together with its memberships and team links.
+// All rows share the deletion time, so RestoreProjectTx can bring them back.
+// Positions, tasks and milestones are left as they are, they are only reachable through the project.
+// It returns sql.ErrNoRows if the project does not exist.
+func (store *Store) DeleteProjectTx(ctx context.Context, projectID int32) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetProject(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteProject(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteProjectMemberByProjectId(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteTeamProjectByProjectId(ctx, projectID)
+	})
+}
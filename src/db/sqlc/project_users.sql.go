@@ -7,6 +7,8 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/lib/pq"
 )
 
 const createProjectMember = `-- name: CreateProjectMember :one
@@ -35,7 +37,7 @@ func (q *Queries) CreateProjectMember(ctx context.Context, arg CreateProjectMemb
 }
 
 const deleteProjectMember = `-- name: DeleteProjectMember :exec
-UPDATE project_users SET deleted_at = NOW() WHERE user_id = $1 AND project_id = $2
+UPDATE project_users SET deleted_at = NOW() WHERE user_id = $1 AND project_id = $2 AND deleted_at IS NULL
 `
 
 type DeleteProjectMemberParams struct {
@@ -49,7 +51,7 @@ func (q *Queries) DeleteProjectMember(ctx context.Context, arg DeleteProjectMemb
 }
 
 const deleteProjectMemberByProjectId = `-- name: DeleteProjectMemberByProjectId :exec
-UPDATE project_users SET deleted_at = NOW() WHERE project_id = $1
+UPDATE project_users SET deleted_at = NOW() WHERE project_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteProjectMemberByProjectId(ctx context.Context, projectID int32) error {
@@ -58,7 +60,7 @@ func (q *Queries) DeleteProjectMemberByProjectId(ctx context.Context, projectID
 }
 
 const deleteProjectMemberByUserId = `-- name: DeleteProjectMemberByUserId :exec
-UPDATE project_users SET deleted_at = NOW() WHERE user_id= $1
+UPDATE project_users SET deleted_at = NOW() WHERE user_id= $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteProjectMemberByUserId(ctx context.Context, userID int32) error {
@@ -67,7 +69,9 @@ func (q *Queries) DeleteProjectMemberByUserId(ctx context.Context, userID int32)
 }
 
 const getProjectLeadByProjectId = `-- name: GetProjectLeadByProjectId :many
-SELECT user_id FROM project_users WHERE project_id = $1 AND role = 'lead' AND  deleted_at IS NULL
+SELECT pu.user_id FROM project_users pu
+JOIN users u ON u.id = pu.user_id
+WHERE pu.project_id = $1 AND pu.role = 'lead' AND pu.deleted_at IS NULL AND u.deleted_at IS NULL
 `
 
 func (q *Queries) GetProjectLeadByProjectId(ctx context.Context, projectID int32) ([]int32, error) {
@@ -93,6 +97,50 @@ func (q *Queries) GetProjectLeadByProjectId(ctx context.Context, projectID int32
 	return items, nil
 }
 
+const getProjectLeadsWithDetails = `-- name: GetProjectLeadsWithDetails :many
+SELECT u.id, u.name, u.last_name, u.email, u.password, u.telephone_number, u.university, u.department, u.date_of_birth, u.role, u.created_at, u.updated_at, u.deleted_at FROM project_users pu
+JOIN users u ON u.id = pu.user_id
+WHERE pu.project_id = $1 AND pu.role = 'lead' AND pu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY pu.id
+`
+
+func (q *Queries) GetProjectLeadsWithDetails(ctx context.Context, projectID int32) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectLeadsWithDetails, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LastName,
+			&i.Email,
+			&i.Password,
+			&i.TelephoneNumber,
+			&i.University,
+			&i.Department,
+			&i.DateOfBirth,
+			&i.Role,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProjectMember = `-- name: GetProjectMember :one
 SELECT id, project_id, user_id, role, created_at, updated_at, deleted_at FROM project_users WHERE user_id = $1 AND project_id = $2 AND deleted_at IS NULL
 `
@@ -118,22 +166,70 @@ func (q *Queries) GetProjectMember(ctx context.Context, arg GetProjectMemberPara
 }
 
 const getProjectsByUserId = `-- name: GetProjectsByUserId :many
-SELECT project_id FROM project_users where user_id = $1 AND deleted_at is NULL
+SELECT p.id, p.name, p.description, p.created_at, p.updated_at, p.deleted_at, p.created_by, p.approval_status, p.status, p.start_date, p.end_date, p.summary, p.tech_stack, p.repository_url, p.demo_url, p.cover_image_id, p.is_published FROM project_users pu
+JOIN projects p ON p.id = pu.project_id
+WHERE pu.user_id = $1 AND pu.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY p.id
 `
 
-func (q *Queries) GetProjectsByUserId(ctx context.Context, userID int32) ([]int32, error) {
+func (q *Queries) GetProjectsByUserId(ctx context.Context, userID int32) ([]Project, error) {
 	rows, err := q.db.QueryContext(ctx, getProjectsByUserId, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CreatedBy,
+			&i.ApprovalStatus,
+			&i.Status,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Summary,
+			pq.Array(&i.TechStack),
+			&i.RepositoryUrl,
+			&i.DemoUrl,
+			&i.CoverImageID,
+			&i.IsPublished,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockProjectLeadsByProjectId = `-- name: LockProjectLeadsByProjectId :many
+SELECT user_id FROM project_users WHERE project_id = $1 AND role = 'lead' AND deleted_at IS NULL FOR UPDATE
+`
+
+func (q *Queries) LockProjectLeadsByProjectId(ctx context.Context, projectID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, lockProjectLeadsByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	items := []int32{}
 	for rows.Next() {
-		var project_id int32
-		if err := rows.Scan(&project_id); err != nil {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
 			return nil, err
 		}
-		items = append(items, project_id)
+		items = append(items, user_id)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -143,3 +239,68 @@ func (q *Queries) GetProjectsByUserId(ctx context.Context, userID int32) ([]int3
 	}
 	return items, nil
 }
+
+const lockProjectLeadsOfUser = `-- name: LockProjectLeadsOfUser :many
+SELECT pu.project_id, pu.user_id FROM project_users pu
+WHERE pu.role = 'lead' AND pu.deleted_at IS NULL
+    AND pu.project_id IN (
+        SELECT l.project_id FROM project_users l
+        JOIN projects p ON p.id = l.project_id
+        WHERE l.user_id = $1 AND l.role = 'lead' AND l.deleted_at IS NULL AND p.deleted_at IS NULL
+    )
+FOR UPDATE
+`
+
+type LockProjectLeadsOfUserRow struct {
+	ProjectID int32 `json:"project_id"`
+	UserID    int32 `json:"user_id"`
+}
+
+// locks the lead rows of every active project the user leads, so no lead can be demoted while the user is deleted
+func (q *Queries) LockProjectLeadsOfUser(ctx context.Context, userID int32) ([]LockProjectLeadsOfUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, lockProjectLeadsOfUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LockProjectLeadsOfUserRow{}
+	for rows.Next() {
+		var i LockProjectLeadsOfUserRow
+		if err := rows.Scan(&i.ProjectID, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProjectMemberRole = `-- name: UpdateProjectMemberRole :one
+UPDATE project_users SET role = $3, updated_at = NOW() WHERE project_id = $1 AND user_id = $2 AND deleted_at IS NULL RETURNING id, project_id, user_id, role, created_at, updated_at, deleted_at
+`
+
+type UpdateProjectMemberRoleParams struct {
+	ProjectID int32  `json:"project_id"`
+	UserID    int32  `json:"user_id"`
+	Role      string `json:"role"`
+}
+
+func (q *Queries) UpdateProjectMemberRole(ctx context.Context, arg UpdateProjectMemberRoleParams) (ProjectUser, error) {
+	row := q.db.QueryRowContext(ctx, updateProjectMemberRole, arg.ProjectID, arg.UserID, arg.Role)
+	var i ProjectUser
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -7,29 +7,65 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
 )
 
+const countProjects = `-- name: CountProjects :one
+SELECT COUNT(*) FROM projects WHERE deleted_at IS NULL AND approval_status = 'approved'
+`
+
+func (q *Queries) CountProjects(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProjects)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPublishedProjects = `-- name: CountPublishedProjects :one
+SELECT COUNT(*) FROM projects WHERE is_published AND approval_status = 'approved' AND deleted_at IS NULL
+`
+
+func (q *Queries) CountPublishedProjects(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPublishedProjects)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProject = `-- name: CreateProject :one
 INSERT INTO projects (
     name,
     description,
+    created_by,
+    approval_status,
     created_at,
     updated_at
 ) VALUES (
     $1,
     $2,
+    $3,
+    $4,
     NOW(),
     NOW()
-) RETURNING id, name, description, created_at, updated_at, deleted_at
+) RETURNING id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published
 `
 
 type CreateProjectParams struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	CreatedBy      sql.NullInt32 `json:"created_by"`
+	ApprovalStatus string        `json:"approval_status"`
 }
 
 func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error) {
-	row := q.db.QueryRowContext(ctx, createProject, arg.Name, arg.Description)
+	row := q.db.QueryRowContext(ctx, createProject,
+		arg.Name,
+		arg.Description,
+		arg.CreatedBy,
+		arg.ApprovalStatus,
+	)
 	var i Project
 	err := row.Scan(
 		&i.ID,
@@ -38,6 +74,17 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
 	)
 	return i, err
 }
@@ -46,7 +93,7 @@ const deleteProject = `-- name: DeleteProject :exec
 UPDATE projects set
     deleted_at = NOW()
 WHERE
-    id = $1
+    id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteProject(ctx context.Context, id int32) error {
@@ -55,7 +102,7 @@ func (q *Queries) DeleteProject(ctx context.Context, id int32) error {
 }
 
 const getAllProjects = `-- name: GetAllProjects :many
-SELECT id, name, description, created_at, updated_at, deleted_at FROM projects WHERE deleted_at IS NULL LIMIT $1 OFFSET $2
+SELECT id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published FROM projects WHERE deleted_at IS NULL AND approval_status = 'approved' ORDER BY id LIMIT $1 OFFSET $2
 `
 
 type GetAllProjectsParams struct {
@@ -79,6 +126,112 @@ func (q *Queries) GetAllProjects(ctx context.Context, arg GetAllProjectsParams)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.CreatedBy,
+			&i.ApprovalStatus,
+			&i.Status,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Summary,
+			pq.Array(&i.TechStack),
+			&i.RepositoryUrl,
+			&i.DemoUrl,
+			&i.CoverImageID,
+			&i.IsPublished,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllProjectsAfterCursor = `-- name: GetAllProjectsAfterCursor :many
+SELECT id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published FROM projects WHERE deleted_at IS NULL AND approval_status = 'approved' AND id > $1 ORDER BY id LIMIT $2
+`
+
+type GetAllProjectsAfterCursorParams struct {
+	ID    int32 `json:"id"`
+	Limit int32 `json:"limit"`
+}
+
+func (q *Queries) GetAllProjectsAfterCursor(ctx context.Context, arg GetAllProjectsAfterCursorParams) ([]Project, error) {
+	rows, err := q.db.QueryContext(ctx, getAllProjectsAfterCursor, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CreatedBy,
+			&i.ApprovalStatus,
+			&i.Status,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Summary,
+			pq.Array(&i.TechStack),
+			&i.RepositoryUrl,
+			&i.DemoUrl,
+			&i.CoverImageID,
+			&i.IsPublished,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingProjects = `-- name: GetPendingProjects :many
+SELECT id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published FROM projects WHERE deleted_at IS NULL AND approval_status = 'pending' ORDER BY id
+`
+
+func (q *Queries) GetPendingProjects(ctx context.Context) ([]Project, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingProjects)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CreatedBy,
+			&i.ApprovalStatus,
+			&i.Status,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Summary,
+			pq.Array(&i.TechStack),
+			&i.RepositoryUrl,
+			&i.DemoUrl,
+			&i.CoverImageID,
+			&i.IsPublished,
 		); err != nil {
 			return nil, err
 		}
@@ -94,7 +247,7 @@ func (q *Queries) GetAllProjects(ctx context.Context, arg GetAllProjectsParams)
 }
 
 const getProject = `-- name: GetProject :one
-SELECT id, name, description, created_at, updated_at, deleted_at FROM projects WHERE id = $1 AND deleted_at IS NULL
+SELECT id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published FROM projects WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetProject(ctx context.Context, id int32) (Project, error) {
@@ -107,6 +260,224 @@ func (q *Queries) GetProject(ctx context.Context, id int32) (Project, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
+	)
+	return i, err
+}
+
+const getProjectApprovalStatus = `-- name: GetProjectApprovalStatus :one
+SELECT approval_status FROM projects WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetProjectApprovalStatus(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRowContext(ctx, getProjectApprovalStatus, id)
+	var approval_status string
+	err := row.Scan(&approval_status)
+	return approval_status, err
+}
+
+const getPublicProjectMembers = `-- name: GetPublicProjectMembers :many
+SELECT
+    u.name,
+    u.last_name,
+    pu.role
+FROM project_users pu
+JOIN users u ON u.id = pu.user_id
+WHERE pu.project_id = $1 AND pu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY pu.role, pu.id
+`
+
+type GetPublicProjectMembersRow struct {
+	Name     string `json:"name"`
+	LastName string `json:"last_name"`
+	Role     string `json:"role"`
+}
+
+func (q *Queries) GetPublicProjectMembers(ctx context.Context, projectID int32) ([]GetPublicProjectMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicProjectMembers, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublicProjectMembersRow{}
+	for rows.Next() {
+		var i GetPublicProjectMembersRow
+		if err := rows.Scan(&i.Name, &i.LastName, &i.Role); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublishedProject = `-- name: GetPublishedProject :one
+SELECT
+    p.id,
+    p.name,
+    p.summary,
+    p.status,
+    p.tech_stack,
+    p.repository_url,
+    p.demo_url,
+    i.url AS cover_image_url
+FROM projects p
+LEFT JOIN images i ON i.id = p.cover_image_id
+WHERE p.id = $1 AND p.is_published AND p.approval_status = 'approved' AND p.deleted_at IS NULL
+`
+
+type GetPublishedProjectRow struct {
+	ID            int32          `json:"id"`
+	Name          string         `json:"name"`
+	Summary       string         `json:"summary"`
+	Status        string         `json:"status"`
+	TechStack     []string       `json:"tech_stack"`
+	RepositoryUrl string         `json:"repository_url"`
+	DemoUrl       string         `json:"demo_url"`
+	CoverImageUrl sql.NullString `json:"cover_image_url"`
+}
+
+func (q *Queries) GetPublishedProject(ctx context.Context, id int32) (GetPublishedProjectRow, error) {
+	row := q.db.QueryRowContext(ctx, getPublishedProject, id)
+	var i GetPublishedProjectRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Summary,
+		&i.Status,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageUrl,
+	)
+	return i, err
+}
+
+const getPublishedProjects = `-- name: GetPublishedProjects :many
+SELECT
+    p.id,
+    p.name,
+    p.summary,
+    p.status,
+    p.tech_stack,
+    p.repository_url,
+    p.demo_url,
+    i.url AS cover_image_url
+FROM projects p
+LEFT JOIN images i ON i.id = p.cover_image_id
+WHERE p.is_published AND p.approval_status = 'approved' AND p.deleted_at IS NULL AND p.id > $1
+ORDER BY p.id
+LIMIT $2
+`
+
+type GetPublishedProjectsParams struct {
+	ID    int32 `json:"id"`
+	Limit int32 `json:"limit"`
+}
+
+type GetPublishedProjectsRow struct {
+	ID            int32          `json:"id"`
+	Name          string         `json:"name"`
+	Summary       string         `json:"summary"`
+	Status        string         `json:"status"`
+	TechStack     []string       `json:"tech_stack"`
+	RepositoryUrl string         `json:"repository_url"`
+	DemoUrl       string         `json:"demo_url"`
+	CoverImageUrl sql.NullString `json:"cover_image_url"`
+}
+
+func (q *Queries) GetPublishedProjects(ctx context.Context, arg GetPublishedProjectsParams) ([]GetPublishedProjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublishedProjects, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublishedProjectsRow{}
+	for rows.Next() {
+		var i GetPublishedProjectsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Summary,
+			&i.Status,
+			pq.Array(&i.TechStack),
+			&i.RepositoryUrl,
+			&i.DemoUrl,
+			&i.CoverImageUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockProject = `-- name: LockProject :one
+SELECT id FROM projects WHERE id = $1 AND deleted_at IS NULL FOR NO KEY UPDATE
+`
+
+func (q *Queries) LockProject(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, lockProject, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const reviewProject = `-- name: ReviewProject :one
+UPDATE projects set
+    approval_status = $2,
+    updated_at = NOW()
+WHERE
+    id = $1 AND approval_status = 'pending' AND deleted_at IS NULL
+RETURNING id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published
+`
+
+type ReviewProjectParams struct {
+	ID             int32  `json:"id"`
+	ApprovalStatus string `json:"approval_status"`
+}
+
+func (q *Queries) ReviewProject(ctx context.Context, arg ReviewProjectParams) (Project, error) {
+	row := q.db.QueryRowContext(ctx, reviewProject, arg.ID, arg.ApprovalStatus)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
 	)
 	return i, err
 }
@@ -115,20 +486,137 @@ const updateProject = `-- name: UpdateProject :one
 UPDATE projects set
     name = $1,
     description = $2,
+    start_date = $4,
+    end_date = $5,
     updated_at = NOW()
 WHERE
     id = $3
-RETURNING id, name, description, created_at, updated_at, deleted_at
+RETURNING id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published
 `
 
 type UpdateProjectParams struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ID          int32  `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	ID          int32        `json:"id"`
+	StartDate   sql.NullTime `json:"start_date"`
+	EndDate     sql.NullTime `json:"end_date"`
 }
 
 func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error) {
-	row := q.db.QueryRowContext(ctx, updateProject, arg.Name, arg.Description, arg.ID)
+	row := q.db.QueryRowContext(ctx, updateProject,
+		arg.Name,
+		arg.Description,
+		arg.ID,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
+	)
+	return i, err
+}
+
+const updateProjectShowcase = `-- name: UpdateProjectShowcase :one
+UPDATE projects set
+    summary = $2,
+    tech_stack = $3,
+    repository_url = $4,
+    demo_url = $5,
+    cover_image_id = $6,
+    is_published = $7,
+    updated_at = NOW()
+WHERE
+    id = $1
+RETURNING id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published
+`
+
+type UpdateProjectShowcaseParams struct {
+	ID            int32         `json:"id"`
+	Summary       string        `json:"summary"`
+	TechStack     []string      `json:"tech_stack"`
+	RepositoryUrl string        `json:"repository_url"`
+	DemoUrl       string        `json:"demo_url"`
+	CoverImageID  sql.NullInt32 `json:"cover_image_id"`
+	IsPublished   bool          `json:"is_published"`
+}
+
+func (q *Queries) UpdateProjectShowcase(ctx context.Context, arg UpdateProjectShowcaseParams) (Project, error) {
+	row := q.db.QueryRowContext(ctx, updateProjectShowcase,
+		arg.ID,
+		arg.Summary,
+		pq.Array(arg.TechStack),
+		arg.RepositoryUrl,
+		arg.DemoUrl,
+		arg.CoverImageID,
+		arg.IsPublished,
+	)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
+	)
+	return i, err
+}
+
+const updateProjectStatus = `-- name: UpdateProjectStatus :one
+UPDATE projects set
+    status = $1,
+    start_date = $2,
+    end_date = $3,
+    updated_at = NOW()
+WHERE
+    id = $4 AND status = $5 AND deleted_at IS NULL
+RETURNING id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published
+`
+
+type UpdateProjectStatusParams struct {
+	Status     string       `json:"status"`
+	StartDate  sql.NullTime `json:"start_date"`
+	EndDate    sql.NullTime `json:"end_date"`
+	ID         int32        `json:"id"`
+	FromStatus string       `json:"from_status"`
+}
+
+func (q *Queries) UpdateProjectStatus(ctx context.Context, arg UpdateProjectStatusParams) (Project, error) {
+	row := q.db.QueryRowContext(ctx, updateProjectStatus,
+		arg.Status,
+		arg.StartDate,
+		arg.EndDate,
+		arg.ID,
+		arg.FromStatus,
+	)
 	var i Project
 	err := row.Scan(
 		&i.ID,
@@ -137,6 +625,17 @@ func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
 	)
 	return i, err
 }
@@ -0,0 +1,83 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrLastLead is returned when a change would leave a team or project without any lead
+	ErrLastLead = errors.New("at least one lead must remain")
+	// ErrNotLead is returned when leadership is transferred from a user who is not a lead
+	ErrNotLead = errors.New("user is not a lead")
+	// ErrNotMember is returned when a role is given to a user who is not a member
+	ErrNotMember = errors.New("user is not a member")
+	// ErrTeamCycle is returned when a team would become its own ancestor
+	ErrTeamCycle = errors.New("a team can not be placed under itself or one of its sub-teams")
+)
+
+// Store provides all functions to execute db queries and transactions
+type Store struct {
+	*Queries
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{
+		Queries: New(newTracedDB(db)),
+		db:      db,
+	}
+}
+
+// execTx executes a function within a database transaction
+func (store *Store) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	q := New(newTracedDB(tx))
+	err = fn(q)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func containsUser(userIDs []int32, userID int32) bool {
+	for _, id := range userIDs {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOnlyLead reports whether removing the user from the given leads would leave none
+func isOnlyLead(leadIDs []int32, userID int32) bool {
+	return len(leadIDs) == 1 && leadIDs[0] == userID
+}
+
+// Ping checks that the database is reachable
+func (store *Store) Ping(ctx context.Context) error {
+	return store.db.PingContext(ctx)
+}
+
+// SchemaVersion returns the migration version recorded by golang-migrate,
+// it is read by hand because sqlc does not know the schema_migrations table
+func (store *Store) SchemaVersion(ctx context.Context) (version int64, dirty bool, err error) {
+	err = store.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	return
+}
+
+// DB returns the connection pool, it is only meant for reading pool statistics
+func (store *Store) DB() *sql.DB {
+	return store.db
+}
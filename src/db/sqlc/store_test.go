@@ -0,0 +1,341 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"yildizskylab/src/db/migration"
+
+	"github.com/lib/pq"
+)
+
+func TestIsOnlyLead(t *testing.T) {
+	tests := []struct {
+		name    string
+		leadIDs []int32
+		userID  int32
+		want    bool
+	}{
+		{"no leads", nil, 1, false},
+		{"the only lead", []int32{1}, 1, true},
+		{"another lead", []int32{2}, 1, false},
+		{"one of two leads", []int32{1, 2}, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOnlyLead(tt.leadIDs, tt.userID); got != tt.want {
+				t.Errorf("isOnlyLead(%v, %d) = %t, want %t", tt.leadIDs, tt.userID, got, tt.want)
+			}
+		})
+	}
+}
+
+// the tests below need a disposable postgres database in TEST_DB_SOURCE,
+// the migrations are applied to it and the created rows are left behind
+
+var fixtureSeq atomic.Int64
+
+// testStore skips the test when no database is configured
+func testStore(t *testing.T) *Store {
+	t.Helper()
+
+	dbSource := os.Getenv("TEST_DB_SOURCE")
+	if dbSource == "" {
+		t.Skip("TEST_DB_SOURCE is not set")
+	}
+
+	if err := migration.Up(dbSource); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", dbSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewStore(db)
+}
+
+// fixtureName makes the names and emails of a test unique across runs against the same database
+func fixtureName(prefix string) string {
+	return fmt.Sprintf("%s-%d-%d", prefix, time.Now().UnixNano(), fixtureSeq.Add(1))
+}
+
+func createTestUser(t *testing.T, store *Store) User {
+	t.Helper()
+
+	user, err := store.CreateUser(context.Background(), CreateUserParams{
+		Name:        "Test",
+		LastName:    "User",
+		Email:       fixtureName("user") + "@store.test",
+		Password:    "not a hash",
+		Role:        "member",
+		DateOfBirth: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func createTestTeam(t *testing.T, store *Store, parentID sql.NullInt32) Team {
+	t.Helper()
+
+	team, err := store.CreateTeam(context.Background(), CreateTeamParams{Name: fixtureName("team"), ParentID: parentID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return team
+}
+
+func createTestProject(t *testing.T, store *Store) Project {
+	t.Helper()
+
+	project, err := store.CreateProject(context.Background(), CreateProjectParams{Name: fixtureName("project"), ApprovalStatus: "approved"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return project
+}
+
+func addTestTeamMember(t *testing.T, store *Store, teamID, userID int32, role string) {
+	t.Helper()
+
+	if _, err := store.CreateTeamMember(context.Background(), CreateTeamMemberParams{TeamID: teamID, UserID: userID, Role: role}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func addTestProjectMember(t *testing.T, store *Store, projectID, userID int32, role string) {
+	t.Helper()
+
+	if _, err := store.CreateProjectMember(context.Background(), CreateProjectMemberParams{ProjectID: projectID, UserID: userID, Role: role}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteUserTxKeepsALead(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	t.Run("only lead of a team", func(t *testing.T) {
+		user := createTestUser(t, store)
+		team := createTestTeam(t, store, sql.NullInt32{})
+		addTestTeamMember(t, store, team.ID, user.ID, "lead")
+
+		if err := store.DeleteUserTx(ctx, user.ID); !errors.Is(err, ErrLastLead) {
+			t.Fatalf("DeleteUserTx() = %v, want ErrLastLead", err)
+		}
+
+		if _, err := store.GetUser(ctx, user.ID); err != nil {
+			t.Fatalf("user is gone after a refused delete: %v", err)
+		}
+	})
+
+	t.Run("only lead of a project", func(t *testing.T) {
+		user := createTestUser(t, store)
+		project := createTestProject(t, store)
+		addTestProjectMember(t, store, project.ID, user.ID, "lead")
+
+		if err := store.DeleteUserTx(ctx, user.ID); !errors.Is(err, ErrLastLead) {
+			t.Fatalf("DeleteUserTx() = %v, want ErrLastLead", err)
+		}
+	})
+
+	t.Run("one of two leads", func(t *testing.T) {
+		user := createTestUser(t, store)
+		other := createTestUser(t, store)
+		team := createTestTeam(t, store, sql.NullInt32{})
+		project := createTestProject(t, store)
+		addTestTeamMember(t, store, team.ID, user.ID, "lead")
+		addTestTeamMember(t, store, team.ID, other.ID, "lead")
+		addTestProjectMember(t, store, project.ID, user.ID, "lead")
+		addTestProjectMember(t, store, project.ID, other.ID, "lead")
+
+		if err := store.DeleteUserTx(ctx, user.ID); err != nil {
+			t.Fatalf("DeleteUserTx() = %v", err)
+		}
+
+		if _, err := store.GetTeamMember(ctx, GetTeamMemberParams{TeamID: team.ID, UserID: user.ID}); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("team membership of the deleted user: %v, want sql.ErrNoRows", err)
+		}
+
+		if _, err := store.GetProjectMember(ctx, GetProjectMemberParams{ProjectID: project.ID, UserID: user.ID}); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("project membership of the deleted user: %v, want sql.ErrNoRows", err)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		if err := store.DeleteUserTx(ctx, -1); !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("DeleteUserTx() = %v, want sql.ErrNoRows", err)
+		}
+	})
+}
+
+func TestUpdateTeamParentTxRejectsCycles(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	root := createTestTeam(t, store, sql.NullInt32{})
+	child := createTestTeam(t, store, sql.NullInt32{Int32: root.ID, Valid: true})
+	grandchild := createTestTeam(t, store, sql.NullInt32{Int32: child.ID, Valid: true})
+	other := createTestTeam(t, store, sql.NullInt32{})
+
+	tests := []struct {
+		name   string
+		teamID int32
+		parent sql.NullInt32
+		err    error
+	}{
+		{"under itself", root.ID, sql.NullInt32{Int32: root.ID, Valid: true}, ErrTeamCycle},
+		{"under its child", root.ID, sql.NullInt32{Int32: child.ID, Valid: true}, ErrTeamCycle},
+		{"under its grandchild", root.ID, sql.NullInt32{Int32: grandchild.ID, Valid: true}, ErrTeamCycle},
+		{"under an unknown team", root.ID, sql.NullInt32{Int32: -1, Valid: true}, sql.ErrNoRows},
+		{"under an unrelated team", root.ID, sql.NullInt32{Int32: other.ID, Valid: true}, nil},
+		{"a sub-team under another branch", grandchild.ID, sql.NullInt32{Int32: other.ID, Valid: true}, nil},
+		{"to the top level", child.ID, sql.NullInt32{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, err := store.UpdateTeamParentTx(ctx, UpdateTeamParentTxParams{TeamID: tt.teamID, ParentID: tt.parent})
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("UpdateTeamParentTx() = %v, want %v", err, tt.err)
+			}
+
+			if err == nil && team.ParentID != tt.parent {
+				t.Errorf("parent = %v, want %v", team.ParentID, tt.parent)
+			}
+		})
+	}
+}
+
+func TestRestoreTeamTxOnlyRevivesMembersDeletedWithTheTeam(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	team := createTestTeam(t, store, sql.NullInt32{})
+	leadUser := createTestUser(t, store)
+	stayed := createTestUser(t, store)
+	left := createTestUser(t, store)
+	addTestTeamMember(t, store, team.ID, leadUser.ID, "lead")
+	addTestTeamMember(t, store, team.ID, stayed.ID, "member")
+	addTestTeamMember(t, store, team.ID, left.ID, "member")
+
+	// left the team before it was deleted, the restore must not bring them back
+	if err := store.RemoveTeamMemberTx(ctx, DeleteTeamMemberParams{TeamID: team.ID, UserID: left.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.DeleteTeamTx(ctx, team.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := store.RestoreTeamTx(ctx, team.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.RestoredMembers != 2 {
+		t.Errorf("restored members = %d, want 2", result.RestoredMembers)
+	}
+
+	for _, userID := range []int32{leadUser.ID, stayed.ID} {
+		if _, err := store.GetTeamMember(ctx, GetTeamMemberParams{TeamID: team.ID, UserID: userID}); err != nil {
+			t.Errorf("member %d was not restored: %v", userID, err)
+		}
+	}
+
+	if _, err := store.GetTeamMember(ctx, GetTeamMemberParams{TeamID: team.ID, UserID: left.ID}); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("member who left before the delete: %v, want sql.ErrNoRows", err)
+	}
+
+	if _, err := store.RestoreTeamTx(ctx, team.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("restoring a team that is not in the trash = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestRestoreUserTxOnlyRevivesMembershipsDeletedWithTheUser(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	user := createTestUser(t, store)
+	kept := createTestProject(t, store)
+	left := createTestProject(t, store)
+	addTestProjectMember(t, store, kept.ID, user.ID, "member")
+	addTestProjectMember(t, store, left.ID, user.ID, "member")
+
+	if err := store.RemoveProjectMemberTx(ctx, DeleteProjectMemberParams{ProjectID: left.ID, UserID: user.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.DeleteUserTx(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := store.RestoreUserTx(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.RestoredProjectMembers != 1 {
+		t.Errorf("restored project members = %d, want 1", result.RestoredProjectMembers)
+	}
+
+	if _, err := store.GetProjectMember(ctx, GetProjectMemberParams{ProjectID: kept.ID, UserID: user.ID}); err != nil {
+		t.Errorf("membership deleted with the user was not restored: %v", err)
+	}
+
+	if _, err := store.GetProjectMember(ctx, GetProjectMemberParams{ProjectID: left.ID, UserID: user.ID}); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("membership removed before the delete: %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestUserEmailIsUniqueIgnoringCase(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	user := createTestUser(t, store)
+
+	_, err := store.CreateUser(ctx, CreateUserParams{
+		Name:        "Test",
+		LastName:    "User",
+		Email:       strings.ToUpper(user.Email),
+		Password:    "not a hash",
+		Role:        "member",
+		DateOfBirth: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	// signup answers a lost race on this index like a new signup, so the constraint name must not change
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "23505" || pqErr.Constraint != "users_email_active_idx" {
+		t.Fatalf("CreateUser() with the email in another case = %v, want a unique violation on users_email_active_idx", err)
+	}
+
+	if err := store.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.CreateUser(ctx, CreateUserParams{
+		Name:        "Test",
+		LastName:    "User",
+		Email:       user.Email,
+		Password:    "not a hash",
+		Role:        "member",
+		DateOfBirth: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}); err != nil {
+		t.Errorf("CreateUser() with the email of a deleted user = %v", err)
+	}
+}
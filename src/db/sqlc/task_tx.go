@@ -0,0 +1,132 @@
+package sqlc
+
+import (
+	"context"
+)
+
+// CreateProjectTaskTx adds a task to the end of its column. The tasks of the project are locked like in a move,
+// and the project itself as well, so tasks created at the same time on an empty board do not share a position.
+func (store *Store) CreateProjectTaskTx(ctx context.Context, arg CreateProjectTaskParams) (ProjectTask, error) {
+	var result ProjectTask
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.LockProject(ctx, arg.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.LockProjectTasksByProjectId(ctx, arg.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateProjectTask(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
+
+type MoveProjectTaskTxParams struct {
+	TaskID   int32  `json:"task_id"`
+	Status   string `json:"status"`
+	Position int32  `json:"position"`
+}
+
+// MoveProjectTaskTx moves a task to the given column and position and renumbers the other tasks
+// of both columns. The tasks of the project are locked, so concurrent moves can not mix up positions.
+func (store *Store) MoveProjectTaskTx(ctx context.Context, arg MoveProjectTaskTxParams) (ProjectTask, error) {
+	var result ProjectTask
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		task, err := lockProjectTask(ctx, q, arg.TaskID)
+		if err != nil {
+			return err
+		}
+
+		err = q.CloseProjectTaskGap(ctx, CloseProjectTaskGapParams{
+			ProjectID: task.ProjectID,
+			Status:    task.Status,
+			Position:  task.Position,
+			ID:        task.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		count, err := q.CountProjectTasksByStatus(ctx, CountProjectTasksByStatusParams{
+			ProjectID: task.ProjectID,
+			Status:    arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		// the moved task is still counted when it stays in its column
+		if arg.Status == task.Status {
+			count--
+		}
+
+		position := arg.Position
+		if int64(position) > count {
+			position = int32(count)
+		}
+
+		err = q.OpenProjectTaskGap(ctx, OpenProjectTaskGapParams{
+			ProjectID: task.ProjectID,
+			Status:    arg.Status,
+			Position:  position,
+			ID:        task.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = q.MoveProjectTask(ctx, MoveProjectTaskParams{
+			ID:       task.ID,
+			Status:   arg.Status,
+			Position: position,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteProjectTaskTx deletes a task and closes the gap it leaves in its column
+func (store *Store) DeleteProjectTaskTx(ctx context.Context, taskID int32) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		task, err := lockProjectTask(ctx, q, taskID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteProjectTask(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.CloseProjectTaskGap(ctx, CloseProjectTaskGapParams{
+			ProjectID: task.ProjectID,
+			Status:    task.Status,
+			Position:  task.Position,
+			ID:        task.ID,
+		})
+	})
+}
+
+// lockProjectTask locks all tasks of the task's project and returns the task as it is after the lock
+func lockProjectTask(ctx context.Context, q *Queries, taskID int32) (ProjectTask, error) {
+	task, err := q.GetProjectTask(ctx, taskID)
+	if err != nil {
+		return task, err
+	}
+
+	_, err = q.LockProjectTasksByProjectId(ctx, task.ProjectID)
+	if err != nil {
+		return task, err
+	}
+
+	// the task may have been moved while waiting for the lock
+	return q.GetProjectTask(ctx, taskID)
+}
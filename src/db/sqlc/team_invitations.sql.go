@@ -0,0 +1,195 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: team_invitations.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const acceptTeamInvitation = `-- name: AcceptTeamInvitation :one
+UPDATE team_invitations SET
+    status = 'accepted',
+    accepted_by = $2,
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND expires_at > NOW() AND deleted_at IS NULL
+RETURNING id, team_id, email, token_hash, status, invited_by, accepted_by, expires_at, created_at, updated_at, deleted_at
+`
+
+type AcceptTeamInvitationParams struct {
+	ID         int32         `json:"id"`
+	AcceptedBy sql.NullInt32 `json:"accepted_by"`
+}
+
+func (q *Queries) AcceptTeamInvitation(ctx context.Context, arg AcceptTeamInvitationParams) (TeamInvitation, error) {
+	row := q.db.QueryRowContext(ctx, acceptTeamInvitation, arg.ID, arg.AcceptedBy)
+	var i TeamInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Email,
+		&i.TokenHash,
+		&i.Status,
+		&i.InvitedBy,
+		&i.AcceptedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const createTeamInvitation = `-- name: CreateTeamInvitation :one
+INSERT INTO team_invitations (team_id,email,token_hash,status,invited_by,expires_at,created_at,updated_at) values ($1,$2,$3,'pending',$4,$5,NOW(),NOW()) RETURNING id, team_id, email, token_hash, status, invited_by, accepted_by, expires_at, created_at, updated_at, deleted_at
+`
+
+type CreateTeamInvitationParams struct {
+	TeamID    int32     `json:"team_id"`
+	Email     string    `json:"email"`
+	TokenHash string    `json:"token_hash"`
+	InvitedBy int32     `json:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateTeamInvitation(ctx context.Context, arg CreateTeamInvitationParams) (TeamInvitation, error) {
+	row := q.db.QueryRowContext(ctx, createTeamInvitation,
+		arg.TeamID,
+		arg.Email,
+		arg.TokenHash,
+		arg.InvitedBy,
+		arg.ExpiresAt,
+	)
+	var i TeamInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Email,
+		&i.TokenHash,
+		&i.Status,
+		&i.InvitedBy,
+		&i.AcceptedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deletePendingTeamInvitationsByTeamId = `-- name: DeletePendingTeamInvitationsByTeamId :exec
+UPDATE team_invitations SET deleted_at = NOW() WHERE team_id = $1 AND status = 'pending' AND deleted_at IS NULL
+`
+
+func (q *Queries) DeletePendingTeamInvitationsByTeamId(ctx context.Context, teamID int32) error {
+	_, err := q.db.ExecContext(ctx, deletePendingTeamInvitationsByTeamId, teamID)
+	return err
+}
+
+const getPendingTeamInvitationsByTeamId = `-- name: GetPendingTeamInvitationsByTeamId :many
+SELECT id, team_id, email, token_hash, status, invited_by, accepted_by, expires_at, created_at, updated_at, deleted_at FROM team_invitations WHERE team_id = $1 AND status = 'pending' AND expires_at > NOW() AND deleted_at IS NULL ORDER BY id
+`
+
+func (q *Queries) GetPendingTeamInvitationsByTeamId(ctx context.Context, teamID int32) ([]TeamInvitation, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingTeamInvitationsByTeamId, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TeamInvitation{}
+	for rows.Next() {
+		var i TeamInvitation
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Email,
+			&i.TokenHash,
+			&i.Status,
+			&i.InvitedBy,
+			&i.AcceptedBy,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTeamInvitation = `-- name: GetTeamInvitation :one
+SELECT id, team_id, email, token_hash, status, invited_by, accepted_by, expires_at, created_at, updated_at, deleted_at FROM team_invitations WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetTeamInvitation(ctx context.Context, id int32) (TeamInvitation, error) {
+	row := q.db.QueryRowContext(ctx, getTeamInvitation, id)
+	var i TeamInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Email,
+		&i.TokenHash,
+		&i.Status,
+		&i.InvitedBy,
+		&i.AcceptedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getTeamInvitationByTokenHash = `-- name: GetTeamInvitationByTokenHash :one
+SELECT i.id, i.team_id, i.email, i.token_hash, i.status, i.invited_by, i.accepted_by, i.expires_at, i.created_at, i.updated_at, i.deleted_at FROM team_invitations i
+JOIN teams t ON t.id = i.team_id
+WHERE i.token_hash = $1 AND i.deleted_at IS NULL AND t.deleted_at IS NULL
+`
+
+func (q *Queries) GetTeamInvitationByTokenHash(ctx context.Context, tokenHash string) (TeamInvitation, error) {
+	row := q.db.QueryRowContext(ctx, getTeamInvitationByTokenHash, tokenHash)
+	var i TeamInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Email,
+		&i.TokenHash,
+		&i.Status,
+		&i.InvitedBy,
+		&i.AcceptedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const revokeTeamInvitation = `-- name: RevokeTeamInvitation :execrows
+UPDATE team_invitations SET
+    status = 'revoked',
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND expires_at > NOW() AND deleted_at IS NULL
+`
+
+func (q *Queries) RevokeTeamInvitation(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeTeamInvitation, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
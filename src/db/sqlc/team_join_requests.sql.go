@@ -0,0 +1,197 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: team_join_requests.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createTeamJoinRequest = `-- name: CreateTeamJoinRequest :one
+INSERT INTO team_join_requests (team_id,user_id,message,status,created_at,updated_at) values ($1,$2,$3,'pending',NOW(),NOW()) RETURNING id, team_id, user_id, message, status, reviewed_by, reviewed_at, created_at, updated_at, deleted_at
+`
+
+type CreateTeamJoinRequestParams struct {
+	TeamID  int32  `json:"team_id"`
+	UserID  int32  `json:"user_id"`
+	Message string `json:"message"`
+}
+
+func (q *Queries) CreateTeamJoinRequest(ctx context.Context, arg CreateTeamJoinRequestParams) (TeamJoinRequest, error) {
+	row := q.db.QueryRowContext(ctx, createTeamJoinRequest, arg.TeamID, arg.UserID, arg.Message)
+	var i TeamJoinRequest
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.UserID,
+		&i.Message,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deletePendingTeamJoinRequestsByTeamId = `-- name: DeletePendingTeamJoinRequestsByTeamId :exec
+UPDATE team_join_requests SET deleted_at = NOW() WHERE team_id = $1 AND status = 'pending' AND deleted_at IS NULL
+`
+
+func (q *Queries) DeletePendingTeamJoinRequestsByTeamId(ctx context.Context, teamID int32) error {
+	_, err := q.db.ExecContext(ctx, deletePendingTeamJoinRequestsByTeamId, teamID)
+	return err
+}
+
+const getPendingTeamJoinRequest = `-- name: GetPendingTeamJoinRequest :one
+SELECT id, team_id, user_id, message, status, reviewed_by, reviewed_at, created_at, updated_at, deleted_at FROM team_join_requests WHERE team_id = $1 AND user_id = $2 AND status = 'pending' AND deleted_at IS NULL
+`
+
+type GetPendingTeamJoinRequestParams struct {
+	TeamID int32 `json:"team_id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) GetPendingTeamJoinRequest(ctx context.Context, arg GetPendingTeamJoinRequestParams) (TeamJoinRequest, error) {
+	row := q.db.QueryRowContext(ctx, getPendingTeamJoinRequest, arg.TeamID, arg.UserID)
+	var i TeamJoinRequest
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.UserID,
+		&i.Message,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getPendingTeamJoinRequestsByTeamId = `-- name: GetPendingTeamJoinRequestsByTeamId :many
+SELECT
+    r.id,
+    r.message,
+    r.created_at,
+    u.id AS user_id,
+    u.name,
+    u.last_name,
+    u.email,
+    u.university,
+    u.department
+FROM team_join_requests r
+JOIN users u ON u.id = r.user_id
+WHERE r.team_id = $1 AND r.status = 'pending' AND r.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY r.id
+`
+
+type GetPendingTeamJoinRequestsByTeamIdRow struct {
+	ID         int32     `json:"id"`
+	Message    string    `json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
+	UserID     int32     `json:"user_id"`
+	Name       string    `json:"name"`
+	LastName   string    `json:"last_name"`
+	Email      string    `json:"email"`
+	University string    `json:"university"`
+	Department string    `json:"department"`
+}
+
+func (q *Queries) GetPendingTeamJoinRequestsByTeamId(ctx context.Context, teamID int32) ([]GetPendingTeamJoinRequestsByTeamIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingTeamJoinRequestsByTeamId, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPendingTeamJoinRequestsByTeamIdRow{}
+	for rows.Next() {
+		var i GetPendingTeamJoinRequestsByTeamIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Message,
+			&i.CreatedAt,
+			&i.UserID,
+			&i.Name,
+			&i.LastName,
+			&i.Email,
+			&i.University,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTeamJoinRequest = `-- name: GetTeamJoinRequest :one
+SELECT r.id, r.team_id, r.user_id, r.message, r.status, r.reviewed_by, r.reviewed_at, r.created_at, r.updated_at, r.deleted_at FROM team_join_requests r
+JOIN teams t ON t.id = r.team_id
+WHERE r.id = $1 AND r.deleted_at IS NULL AND t.deleted_at IS NULL
+`
+
+func (q *Queries) GetTeamJoinRequest(ctx context.Context, id int32) (TeamJoinRequest, error) {
+	row := q.db.QueryRowContext(ctx, getTeamJoinRequest, id)
+	var i TeamJoinRequest
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.UserID,
+		&i.Message,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const reviewTeamJoinRequest = `-- name: ReviewTeamJoinRequest :one
+UPDATE team_join_requests SET
+    status = $2,
+    reviewed_by = $3,
+    reviewed_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = $1 AND status = 'pending' AND deleted_at IS NULL
+RETURNING id, team_id, user_id, message, status, reviewed_by, reviewed_at, created_at, updated_at, deleted_at
+`
+
+type ReviewTeamJoinRequestParams struct {
+	ID         int32         `json:"id"`
+	Status     string        `json:"status"`
+	ReviewedBy sql.NullInt32 `json:"reviewed_by"`
+}
+
+func (q *Queries) ReviewTeamJoinRequest(ctx context.Context, arg ReviewTeamJoinRequestParams) (TeamJoinRequest, error) {
+	row := q.db.QueryRowContext(ctx, reviewTeamJoinRequest, arg.ID, arg.Status, arg.ReviewedBy)
+	var i TeamJoinRequest
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.UserID,
+		&i.Message,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
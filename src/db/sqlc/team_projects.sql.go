@@ -7,6 +7,9 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const createTeamProject = `-- name: CreateTeamProject :one
@@ -33,7 +36,7 @@ func (q *Queries) CreateTeamProject(ctx context.Context, arg CreateTeamProjectPa
 }
 
 const deleteTeamProject = `-- name: DeleteTeamProject :exec
-UPDATE team_projects SET deleted_at = NOW() WHERE team_id = $1 AND project_id = $2
+UPDATE team_projects SET deleted_at = NOW() WHERE team_id = $1 AND project_id = $2 AND deleted_at IS NULL
 `
 
 type DeleteTeamProjectParams struct {
@@ -46,8 +49,28 @@ func (q *Queries) DeleteTeamProject(ctx context.Context, arg DeleteTeamProjectPa
 	return err
 }
 
+const deleteTeamProjectByProjectId = `-- name: DeleteTeamProjectByProjectId :exec
+UPDATE team_projects SET deleted_at = NOW() WHERE project_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteTeamProjectByProjectId(ctx context.Context, projectID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteTeamProjectByProjectId, projectID)
+	return err
+}
+
+const deleteTeamProjectByTeamId = `-- name: DeleteTeamProjectByTeamId :exec
+UPDATE team_projects SET deleted_at = NOW() WHERE team_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteTeamProjectByTeamId(ctx context.Context, teamID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteTeamProjectByTeamId, teamID)
+	return err
+}
+
 const getProjectTeamByProjectId = `-- name: GetProjectTeamByProjectId :many
-SELECT team_id FROM team_projects WHERE project_id = $1 AND deleted_at IS NULL
+SELECT tp.team_id FROM team_projects tp
+JOIN teams t ON t.id = tp.team_id
+WHERE tp.project_id = $1 AND tp.deleted_at IS NULL AND t.deleted_at IS NULL
 `
 
 func (q *Queries) GetProjectTeamByProjectId(ctx context.Context, projectID int32) ([]int32, error) {
@@ -73,8 +96,119 @@ func (q *Queries) GetProjectTeamByProjectId(ctx context.Context, projectID int32
 	return items, nil
 }
 
+const getProjectsByTeamId = `-- name: GetProjectsByTeamId :many
+SELECT p.id, p.name, p.description, p.created_at, p.updated_at, p.deleted_at, p.created_by, p.approval_status, p.status, p.start_date, p.end_date, p.summary, p.tech_stack, p.repository_url, p.demo_url, p.cover_image_id, p.is_published FROM team_projects tp
+JOIN projects p ON p.id = tp.project_id
+WHERE tp.team_id = $1 AND tp.deleted_at IS NULL AND p.deleted_at IS NULL
+ORDER BY p.id
+`
+
+func (q *Queries) GetProjectsByTeamId(ctx context.Context, teamID int32) ([]Project, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectsByTeamId, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CreatedBy,
+			&i.ApprovalStatus,
+			&i.Status,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Summary,
+			pq.Array(&i.TechStack),
+			&i.RepositoryUrl,
+			&i.DemoUrl,
+			&i.CoverImageID,
+			&i.IsPublished,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublishedProjectsByTeamId = `-- name: GetPublishedProjectsByTeamId :many
+SELECT
+    p.id,
+    p.name,
+    p.summary,
+    p.status,
+    p.tech_stack,
+    p.repository_url,
+    p.demo_url,
+    i.url AS cover_image_url
+FROM team_projects tp
+JOIN projects p ON p.id = tp.project_id
+LEFT JOIN images i ON i.id = p.cover_image_id
+WHERE tp.team_id = $1 AND tp.deleted_at IS NULL AND p.deleted_at IS NULL
+    AND p.is_published AND p.approval_status = 'approved'
+ORDER BY p.id
+`
+
+type GetPublishedProjectsByTeamIdRow struct {
+	ID            int32          `json:"id"`
+	Name          string         `json:"name"`
+	Summary       string         `json:"summary"`
+	Status        string         `json:"status"`
+	TechStack     []string       `json:"tech_stack"`
+	RepositoryUrl string         `json:"repository_url"`
+	DemoUrl       string         `json:"demo_url"`
+	CoverImageUrl sql.NullString `json:"cover_image_url"`
+}
+
+func (q *Queries) GetPublishedProjectsByTeamId(ctx context.Context, teamID int32) ([]GetPublishedProjectsByTeamIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublishedProjectsByTeamId, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublishedProjectsByTeamIdRow{}
+	for rows.Next() {
+		var i GetPublishedProjectsByTeamIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Summary,
+			&i.Status,
+			pq.Array(&i.TechStack),
+			&i.RepositoryUrl,
+			&i.DemoUrl,
+			&i.CoverImageUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeamProjectByTeamId = `-- name: GetTeamProjectByTeamId :many
-SELECT project_id FROM team_projects WHERE team_id = $1 AND deleted_at IS NULL
+SELECT tp.project_id FROM team_projects tp
+JOIN projects p ON p.id = tp.project_id
+WHERE tp.team_id = $1 AND tp.deleted_at IS NULL AND p.deleted_at IS NULL
 `
 
 func (q *Queries) GetTeamProjectByTeamId(ctx context.Context, teamID int32) ([]int32, error) {
@@ -99,3 +233,42 @@ func (q *Queries) GetTeamProjectByTeamId(ctx context.Context, teamID int32) ([]i
 	}
 	return items, nil
 }
+
+const getTeamsByProjectId = `-- name: GetTeamsByProjectId :many
+SELECT t.id, t.name, t.description, t.created_at, t.updated_at, t.deleted_at, t.logo_image_id, t.parent_id FROM team_projects tp
+JOIN teams t ON t.id = tp.team_id
+WHERE tp.project_id = $1 AND tp.deleted_at IS NULL AND t.deleted_at IS NULL
+ORDER BY t.id
+`
+
+func (q *Queries) GetTeamsByProjectId(ctx context.Context, projectID int32) ([]Team, error) {
+	rows, err := q.db.QueryContext(ctx, getTeamsByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Team{}
+	for rows.Next() {
+		var i Team
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LogoImageID,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
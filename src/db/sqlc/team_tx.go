@@ -0,0 +1,266 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+)
+
+type ApproveTeamJoinRequestTxParams struct {
+	RequestID  int32 `json:"request_id"`
+	ReviewerID int32 `json:"reviewer_id"`
+}
+
+type ApproveTeamJoinRequestTxResult struct {
+	Request TeamJoinRequest `json:"request"`
+	Member  TeamUser        `json:"member"`
+}
+
+// ApproveTeamJoinRequestTx marks a pending join request as approved and adds the user to the team.
+// It returns sql.ErrNoRows if the request was already reviewed.
+func (store *Store) ApproveTeamJoinRequestTx(ctx context.Context, arg ApproveTeamJoinRequestTxParams) (ApproveTeamJoinRequestTxResult, error) {
+	var result ApproveTeamJoinRequestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Request, err = q.ReviewTeamJoinRequest(ctx, ReviewTeamJoinRequestParams{
+			ID:         arg.RequestID,
+			Status:     "approved",
+			ReviewedBy: sql.NullInt32{Int32: arg.ReviewerID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Member, err = addTeamMemberIfMissing(ctx, q, result.Request.TeamID, result.Request.UserID)
+		return err
+	})
+
+	return result, err
+}
+
+type AcceptTeamInvitationTxParams struct {
+	InvitationID int32 `json:"invitation_id"`
+	UserID       int32 `json:"user_id"`
+}
+
+type AcceptTeamInvitationTxResult struct {
+	Invitation TeamInvitation `json:"invitation"`
+	Member     TeamUser       `json:"member"`
+}
+
+// AcceptTeamInvitationTx marks a pending invitation as accepted and adds the user to the team.
+// It returns sql.ErrNoRows if the invitation is no longer pending or has expired.
+func (store *Store) AcceptTeamInvitationTx(ctx context.Context, arg AcceptTeamInvitationTxParams) (AcceptTeamInvitationTxResult, error) {
+	var result AcceptTeamInvitationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Invitation, err = q.AcceptTeamInvitation(ctx, AcceptTeamInvitationParams{
+			ID:         arg.InvitationID,
+			AcceptedBy: sql.NullInt32{Int32: arg.UserID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Member, err = addTeamMemberIfMissing(ctx, q, result.Invitation.TeamID, arg.UserID)
+		return err
+	})
+
+	return result, err
+}
+
+// addTeamMemberIfMissing keeps the existing membership, so a lead is never demoted by joining again
+func addTeamMemberIfMissing(ctx context.Context, q *Queries, teamID int32, userID int32) (TeamUser, error) {
+	member, err := q.GetTeamMember(ctx, GetTeamMemberParams{
+		TeamID: teamID,
+		UserID: userID,
+	})
+	if err != sql.ErrNoRows {
+		return member, err
+	}
+
+	return q.CreateTeamMember(ctx, CreateTeamMemberParams{
+		TeamID: teamID,
+		UserID: userID,
+		Role:   "member",
+	})
+}
+
+type ChangeTeamMemberRoleTxParams struct {
+	TeamID int32  `json:"team_id"`
+	UserID int32  `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// ChangeTeamMemberRoleTx changes the role of a team member.
+// The leads of the team are locked, so concurrent demotions can not leave the team without a lead.
+func (store *Store) ChangeTeamMemberRoleTx(ctx context.Context, arg ChangeTeamMemberRoleTxParams) (TeamUser, error) {
+	var result TeamUser
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		leads, err := q.LockTeamLeadsByTeamId(ctx, arg.TeamID)
+		if err != nil {
+			return err
+		}
+
+		if arg.Role != "lead" && isOnlyLead(leads, arg.UserID) {
+			return ErrLastLead
+		}
+
+		result, err = q.UpdateTeamMemberRole(ctx, UpdateTeamMemberRoleParams(arg))
+		if err == sql.ErrNoRows {
+			return ErrNotMember
+		}
+		return err
+	})
+
+	return result, err
+}
+
+type TransferTeamLeadershipTxParams struct {
+	TeamID     int32 `json:"team_id"`
+	FromUserID int32 `json:"from_user_id"`
+	ToUserID   int32 `json:"to_user_id"`
+}
+
+type TransferTeamLeadershipTxResult struct {
+	PreviousLead TeamUser `json:"previous_lead"`
+	NewLead      TeamUser `json:"new_lead"`
+}
+
+// TransferTeamLeadershipTx promotes a member to lead and demotes the previous lead in one step
+func (store *Store) TransferTeamLeadershipTx(ctx context.Context, arg TransferTeamLeadershipTxParams) (TransferTeamLeadershipTxResult, error) {
+	var result TransferTeamLeadershipTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		leads, err := q.LockTeamLeadsByTeamId(ctx, arg.TeamID)
+		if err != nil {
+			return err
+		}
+
+		if !containsUser(leads, arg.FromUserID) {
+			return ErrNotLead
+		}
+
+		result.NewLead, err = q.UpdateTeamMemberRole(ctx, UpdateTeamMemberRoleParams{
+			TeamID: arg.TeamID,
+			UserID: arg.ToUserID,
+			Role:   "lead",
+		})
+		if err == sql.ErrNoRows {
+			return ErrNotMember
+		}
+		if err != nil {
+			return err
+		}
+
+		result.PreviousLead, err = q.UpdateTeamMemberRole(ctx, UpdateTeamMemberRoleParams{
+			TeamID: arg.TeamID,
+			UserID: arg.FromUserID,
+			Role:   "member",
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// RemoveTeamMemberTx removes a member from the team unless they are its only lead
+func (store *Store) RemoveTeamMemberTx(ctx context.Context, arg DeleteTeamMemberParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		leads, err := q.LockTeamLeadsByTeamId(ctx, arg.TeamID)
+		if err != nil {
+			return err
+		}
+
+		if isOnlyLead(leads, arg.UserID) {
+			return ErrLastLead
+		}
+
+		return q.DeleteTeamMember(ctx, arg)
+	})
+}
+
+type UpdateTeamParentTxParams struct {
+	TeamID   int32         `json:"team_id"`
+	ParentID sql.NullInt32 `json:"parent_id"`
+}
+
+// UpdateTeamParentTx moves a team under a new parent, or to the top level when ParentID is not valid.
+// Hierarchy changes are serialized so two concurrent moves can not build a cycle together.
+// It returns ErrTeamCycle if the parent is the team itself or one of its sub-teams.
+func (store *Store) UpdateTeamParentTx(ctx context.Context, arg UpdateTeamParentTxParams) (Team, error) {
+	var team Team
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.LockTeamHierarchy(ctx)
+		if err != nil {
+			return err
+		}
+
+		if arg.ParentID.Valid {
+			_, err = q.GetTeam(ctx, arg.ParentID.Int32)
+			if err != nil {
+				return err
+			}
+
+			inSubtree, err := q.IsTeamInSubtree(ctx, IsTeamInSubtreeParams{
+				RootID: arg.TeamID,
+				TeamID: arg.ParentID.Int32,
+			})
+			if err != nil {
+				return err
+			}
+
+			if inSubtree {
+				return ErrTeamCycle
+			}
+		}
+
+		team, err = q.UpdateTeamParent(ctx, UpdateTeamParentParams{
+			ID:       arg.TeamID,
+			ParentID: arg.ParentID,
+		})
+		return err
+	})
+
+	return team, err
+}
+
+// DeleteTeamTx soft-deletes a team together with its memberships, project links and pending join requests and invitations.
+// All rows share the deletion time, so RestoreTeamTx can bring the memberships and links back.
+// Sub-teams are kept and show up at the top level until the team is restored.
+// It returns sql.ErrNoRows if the team does not exist.
+func (store *Store) DeleteTeamTx(ctx context.Context, teamID int32) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetTeam(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteTeam(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteTeamMemberByTeamId(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteTeamProjectByTeamId(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeletePendingTeamJoinRequestsByTeamId(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		return q.DeletePendingTeamInvitationsByTeamId(ctx, teamID)
+	})
+}
@@ -7,8 +7,22 @@ package sqlc
 
 import (
 	"context"
+	"time"
 )
 
+const countTeamMembers = `-- name: CountTeamMembers :one
+SELECT COUNT(*) FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+`
+
+func (q *Queries) CountTeamMembers(ctx context.Context, teamID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTeamMembers, teamID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTeamMember = `-- name: CreateTeamMember :one
 INSERT INTO team_users (team_id,user_id,role,created_at,updated_at) values ($1,$2,$3,NOW(),NOW()) RETURNING id, team_id, user_id, role, created_at, updated_at, deleted_at
 `
@@ -35,7 +49,7 @@ func (q *Queries) CreateTeamMember(ctx context.Context, arg CreateTeamMemberPara
 }
 
 const deleteTeamMember = `-- name: DeleteTeamMember :exec
-UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1 AND user_id = $2
+UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1 AND user_id = $2 AND deleted_at IS NULL
 `
 
 type DeleteTeamMemberParams struct {
@@ -49,7 +63,7 @@ func (q *Queries) DeleteTeamMember(ctx context.Context, arg DeleteTeamMemberPara
 }
 
 const deleteTeamMemberByTeamId = `-- name: DeleteTeamMemberByTeamId :exec
-UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1
+UPDATE team_users SET deleted_at = NOW() WHERE team_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteTeamMemberByTeamId(ctx context.Context, teamID int32) error {
@@ -58,7 +72,7 @@ func (q *Queries) DeleteTeamMemberByTeamId(ctx context.Context, teamID int32) er
 }
 
 const deleteTeamMemberByUserId = `-- name: DeleteTeamMemberByUserId :exec
-UPDATE team_users SET deleted_at = NOW() WHERE user_id = $1
+UPDATE team_users SET deleted_at = NOW() WHERE user_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteTeamMemberByUserId(ctx context.Context, userID int32) error {
@@ -66,8 +80,89 @@ func (q *Queries) DeleteTeamMemberByUserId(ctx context.Context, userID int32) er
 	return err
 }
 
+const getAllPublicTeamLeads = `-- name: GetAllPublicTeamLeads :many
+SELECT
+    tu.team_id,
+    u.name,
+    u.last_name
+FROM team_users tu
+JOIN teams t ON t.id = tu.team_id
+JOIN users u ON u.id = tu.user_id
+WHERE tu.role = 'lead' AND tu.deleted_at IS NULL AND t.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.team_id, tu.id
+`
+
+type GetAllPublicTeamLeadsRow struct {
+	TeamID   int32  `json:"team_id"`
+	Name     string `json:"name"`
+	LastName string `json:"last_name"`
+}
+
+func (q *Queries) GetAllPublicTeamLeads(ctx context.Context) ([]GetAllPublicTeamLeadsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPublicTeamLeads)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllPublicTeamLeadsRow{}
+	for rows.Next() {
+		var i GetAllPublicTeamLeadsRow
+		if err := rows.Scan(&i.TeamID, &i.Name, &i.LastName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicTeamLeads = `-- name: GetPublicTeamLeads :many
+SELECT
+    u.name,
+    u.last_name
+FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.role = 'lead' AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.id
+`
+
+type GetPublicTeamLeadsRow struct {
+	Name     string `json:"name"`
+	LastName string `json:"last_name"`
+}
+
+func (q *Queries) GetPublicTeamLeads(ctx context.Context, teamID int32) ([]GetPublicTeamLeadsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicTeamLeads, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublicTeamLeadsRow{}
+	for rows.Next() {
+		var i GetPublicTeamLeadsRow
+		if err := rows.Scan(&i.Name, &i.LastName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeamLeadByTeamId = `-- name: GetTeamLeadByTeamId :many
-SELECT user_id FROM team_users WHERE team_id = $1 AND role = 'lead' AND  deleted_at IS NULL
+SELECT tu.user_id FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.role = 'lead' AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
 `
 
 func (q *Queries) GetTeamLeadByTeamId(ctx context.Context, teamID int32) ([]int32, error) {
@@ -93,6 +188,50 @@ func (q *Queries) GetTeamLeadByTeamId(ctx context.Context, teamID int32) ([]int3
 	return items, nil
 }
 
+const getTeamLeadsWithDetails = `-- name: GetTeamLeadsWithDetails :many
+SELECT u.id, u.name, u.last_name, u.email, u.password, u.telephone_number, u.university, u.department, u.date_of_birth, u.role, u.created_at, u.updated_at, u.deleted_at FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.role = 'lead' AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.id
+`
+
+func (q *Queries) GetTeamLeadsWithDetails(ctx context.Context, teamID int32) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getTeamLeadsWithDetails, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LastName,
+			&i.Email,
+			&i.Password,
+			&i.TelephoneNumber,
+			&i.University,
+			&i.Department,
+			&i.DateOfBirth,
+			&i.Role,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeamMember = `-- name: GetTeamMember :one
 SELECT id, team_id, user_id, role, created_at, updated_at, deleted_at FROM team_users WHERE team_id = $1 AND user_id = $2 AND deleted_at IS NULL
 `
@@ -117,23 +256,210 @@ func (q *Queries) GetTeamMember(ctx context.Context, arg GetTeamMemberParams) (T
 	return i, err
 }
 
+const getTeamMembersWithDetails = `-- name: GetTeamMembersWithDetails :many
+SELECT
+    tu.id,
+    tu.role AS team_role,
+    tu.created_at AS joined_at,
+    u.id AS user_id,
+    u.name,
+    u.last_name,
+    u.email,
+    u.university,
+    u.department
+FROM team_users tu
+JOIN users u ON u.id = tu.user_id
+WHERE tu.team_id = $1 AND tu.id > $2 AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+ORDER BY tu.id
+LIMIT $3
+`
+
+type GetTeamMembersWithDetailsParams struct {
+	TeamID int32 `json:"team_id"`
+	ID     int32 `json:"id"`
+	Limit  int32 `json:"limit"`
+}
+
+type GetTeamMembersWithDetailsRow struct {
+	ID         int32     `json:"id"`
+	TeamRole   string    `json:"team_role"`
+	JoinedAt   time.Time `json:"joined_at"`
+	UserID     int32     `json:"user_id"`
+	Name       string    `json:"name"`
+	LastName   string    `json:"last_name"`
+	Email      string    `json:"email"`
+	University string    `json:"university"`
+	Department string    `json:"department"`
+}
+
+func (q *Queries) GetTeamMembersWithDetails(ctx context.Context, arg GetTeamMembersWithDetailsParams) ([]GetTeamMembersWithDetailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTeamMembersWithDetails, arg.TeamID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTeamMembersWithDetailsRow{}
+	for rows.Next() {
+		var i GetTeamMembersWithDetailsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamRole,
+			&i.JoinedAt,
+			&i.UserID,
+			&i.Name,
+			&i.LastName,
+			&i.Email,
+			&i.University,
+			&i.Department,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeamsByUserId = `-- name: GetTeamsByUserId :many
-SELECT team_id FROM team_users where user_id = $1 and deleted_at is null
+SELECT t.id, t.name, t.description, t.created_at, t.updated_at, t.deleted_at, t.logo_image_id, t.parent_id FROM team_users tu
+JOIN teams t ON t.id = tu.team_id
+WHERE tu.user_id = $1 AND tu.deleted_at IS NULL AND t.deleted_at IS NULL
+ORDER BY t.id
 `
 
-func (q *Queries) GetTeamsByUserId(ctx context.Context, userID int32) ([]int32, error) {
+func (q *Queries) GetTeamsByUserId(ctx context.Context, userID int32) ([]Team, error) {
 	rows, err := q.db.QueryContext(ctx, getTeamsByUserId, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	items := []Team{}
+	for rows.Next() {
+		var i Team
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LogoImageID,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isLeadOfAnyTeam = `-- name: IsLeadOfAnyTeam :one
+SELECT EXISTS (
+    SELECT 1 FROM team_users WHERE user_id = $1 AND role = 'lead' AND deleted_at IS NULL
+)
+`
+
+func (q *Queries) IsLeadOfAnyTeam(ctx context.Context, userID int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isLeadOfAnyTeam, userID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const isLeadOfTeamOrAncestor = `-- name: IsLeadOfTeamOrAncestor :one
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM teams WHERE id = $2::int AND deleted_at IS NULL
+    UNION
+    SELECT t.id, t.parent_id FROM teams t JOIN ancestors a ON t.id = a.parent_id WHERE t.deleted_at IS NULL
+)
+SELECT EXISTS (
+    SELECT 1 FROM team_users tu
+    JOIN ancestors a ON a.id = tu.team_id
+    WHERE tu.user_id = $1::int AND tu.role = 'lead' AND tu.deleted_at IS NULL
+)
+`
+
+type IsLeadOfTeamOrAncestorParams struct {
+	UserID int32 `json:"user_id"`
+	TeamID int32 `json:"team_id"`
+}
+
+// division leads inherit the lead permissions of every team below them
+func (q *Queries) IsLeadOfTeamOrAncestor(ctx context.Context, arg IsLeadOfTeamOrAncestorParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isLeadOfTeamOrAncestor, arg.UserID, arg.TeamID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const lockTeamLeadsByTeamId = `-- name: LockTeamLeadsByTeamId :many
+SELECT user_id FROM team_users WHERE team_id = $1 AND role = 'lead' AND deleted_at IS NULL FOR UPDATE
+`
+
+func (q *Queries) LockTeamLeadsByTeamId(ctx context.Context, teamID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, lockTeamLeadsByTeamId, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 	items := []int32{}
 	for rows.Next() {
-		var team_id int32
-		if err := rows.Scan(&team_id); err != nil {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTeamLeadsOfUser = `-- name: LockTeamLeadsOfUser :many
+SELECT tu.team_id, tu.user_id FROM team_users tu
+WHERE tu.role = 'lead' AND tu.deleted_at IS NULL
+    AND tu.team_id IN (
+        SELECT l.team_id FROM team_users l
+        JOIN teams t ON t.id = l.team_id
+        WHERE l.user_id = $1 AND l.role = 'lead' AND l.deleted_at IS NULL AND t.deleted_at IS NULL
+    )
+FOR UPDATE
+`
+
+type LockTeamLeadsOfUserRow struct {
+	TeamID int32 `json:"team_id"`
+	UserID int32 `json:"user_id"`
+}
+
+// locks the lead rows of every active team the user leads, so no lead can be demoted while the user is deleted
+func (q *Queries) LockTeamLeadsOfUser(ctx context.Context, userID int32) ([]LockTeamLeadsOfUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, lockTeamLeadsOfUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LockTeamLeadsOfUserRow{}
+	for rows.Next() {
+		var i LockTeamLeadsOfUserRow
+		if err := rows.Scan(&i.TeamID, &i.UserID); err != nil {
 			return nil, err
 		}
-		items = append(items, team_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -143,3 +469,28 @@ func (q *Queries) GetTeamsByUserId(ctx context.Context, userID int32) ([]int32,
 	}
 	return items, nil
 }
+
+const updateTeamMemberRole = `-- name: UpdateTeamMemberRole :one
+UPDATE team_users SET role = $3, updated_at = NOW() WHERE team_id = $1 AND user_id = $2 AND deleted_at IS NULL RETURNING id, team_id, user_id, role, created_at, updated_at, deleted_at
+`
+
+type UpdateTeamMemberRoleParams struct {
+	TeamID int32  `json:"team_id"`
+	UserID int32  `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func (q *Queries) UpdateTeamMemberRole(ctx context.Context, arg UpdateTeamMemberRoleParams) (TeamUser, error) {
+	row := q.db.QueryRowContext(ctx, updateTeamMemberRole, arg.TeamID, arg.UserID, arg.Role)
+	var i TeamUser
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -7,29 +7,45 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const countTeams = `-- name: CountTeams :one
+SELECT COUNT(*) FROM teams WHERE deleted_at IS NULL
+`
+
+func (q *Queries) CountTeams(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTeams)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTeam = `-- name: CreateTeam :one
 INSERT INTO teams (
     name,
     description,
+    parent_id,
     created_at,
     updated_at
 ) VALUES (
     $1,
     $2,
+    $3,
     NOW(),
     NOW()
-) RETURNING id, name, description, created_at, updated_at, deleted_at
+) RETURNING id, name, description, created_at, updated_at, deleted_at, logo_image_id, parent_id
 `
 
 type CreateTeamParams struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	ParentID    sql.NullInt32 `json:"parent_id"`
 }
 
 func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, error) {
-	row := q.db.QueryRowContext(ctx, createTeam, arg.Name, arg.Description)
+	row := q.db.QueryRowContext(ctx, createTeam, arg.Name, arg.Description, arg.ParentID)
 	var i Team
 	err := row.Scan(
 		&i.ID,
@@ -38,6 +54,8 @@ func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LogoImageID,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -46,7 +64,7 @@ const deleteTeam = `-- name: DeleteTeam :exec
 UPDATE teams set
     deleted_at = NOW()
 WHERE
-    id = $1
+    id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteTeam(ctx context.Context, id int32) error {
@@ -55,7 +73,12 @@ func (q *Queries) DeleteTeam(ctx context.Context, id int32) error {
 }
 
 const getAllTeams = `-- name: GetAllTeams :many
-SELECT id, name, description, created_at, updated_at, deleted_at FROM teams WHERE deleted_at IS NULL LIMIT $1 OFFSET $2
+SELECT t.id, t.name, t.description, t.created_at, t.updated_at, t.deleted_at, t.logo_image_id, t.parent_id, (
+    SELECT COUNT(*) FROM team_users tu
+    JOIN users u ON u.id = tu.user_id
+    WHERE tu.team_id = t.id AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+) AS member_count
+FROM teams t WHERE t.deleted_at IS NULL ORDER BY t.id LIMIT $1 OFFSET $2
 `
 
 type GetAllTeamsParams struct {
@@ -63,15 +86,86 @@ type GetAllTeamsParams struct {
 	Offset int32 `json:"offset"`
 }
 
-func (q *Queries) GetAllTeams(ctx context.Context, arg GetAllTeamsParams) ([]Team, error) {
+type GetAllTeamsRow struct {
+	ID          int32         `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	LogoImageID sql.NullInt32 `json:"logo_image_id"`
+	ParentID    sql.NullInt32 `json:"parent_id"`
+	MemberCount int64         `json:"member_count"`
+}
+
+func (q *Queries) GetAllTeams(ctx context.Context, arg GetAllTeamsParams) ([]GetAllTeamsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getAllTeams, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Team{}
+	items := []GetAllTeamsRow{}
+	for rows.Next() {
+		var i GetAllTeamsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LogoImageID,
+			&i.ParentID,
+			&i.MemberCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllTeamsAfterCursor = `-- name: GetAllTeamsAfterCursor :many
+SELECT t.id, t.name, t.description, t.created_at, t.updated_at, t.deleted_at, t.logo_image_id, t.parent_id, (
+    SELECT COUNT(*) FROM team_users tu
+    JOIN users u ON u.id = tu.user_id
+    WHERE tu.team_id = t.id AND tu.deleted_at IS NULL AND u.deleted_at IS NULL
+) AS member_count
+FROM teams t WHERE t.deleted_at IS NULL AND t.id > $1 ORDER BY t.id LIMIT $2
+`
+
+type GetAllTeamsAfterCursorParams struct {
+	ID    int32 `json:"id"`
+	Limit int32 `json:"limit"`
+}
+
+type GetAllTeamsAfterCursorRow struct {
+	ID          int32         `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	LogoImageID sql.NullInt32 `json:"logo_image_id"`
+	ParentID    sql.NullInt32 `json:"parent_id"`
+	MemberCount int64         `json:"member_count"`
+}
+
+func (q *Queries) GetAllTeamsAfterCursor(ctx context.Context, arg GetAllTeamsAfterCursorParams) ([]GetAllTeamsAfterCursorRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllTeamsAfterCursor, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllTeamsAfterCursorRow{}
 	for rows.Next() {
-		var i Team
+		var i GetAllTeamsAfterCursorRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -79,6 +173,144 @@ func (q *Queries) GetAllTeams(ctx context.Context, arg GetAllTeamsParams) ([]Tea
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.LogoImageID,
+			&i.ParentID,
+			&i.MemberCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrgChartTeams = `-- name: GetOrgChartTeams :many
+SELECT
+    t.id,
+    t.name,
+    i.url AS logo_url,
+    (
+        SELECT COUNT(*) FROM team_projects tp
+        JOIN projects p ON p.id = tp.project_id
+        WHERE tp.team_id = t.id AND tp.deleted_at IS NULL AND p.deleted_at IS NULL
+            AND p.is_published AND p.approval_status = 'approved'
+    ) AS project_count
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.deleted_at IS NULL
+ORDER BY t.name, t.id
+`
+
+type GetOrgChartTeamsRow struct {
+	ID           int32          `json:"id"`
+	Name         string         `json:"name"`
+	LogoUrl      sql.NullString `json:"logo_url"`
+	ProjectCount int64          `json:"project_count"`
+}
+
+func (q *Queries) GetOrgChartTeams(ctx context.Context) ([]GetOrgChartTeamsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOrgChartTeams)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOrgChartTeamsRow{}
+	for rows.Next() {
+		var i GetOrgChartTeamsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LogoUrl,
+			&i.ProjectCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublicTeam = `-- name: GetPublicTeam :one
+SELECT
+    t.id,
+    t.name,
+    t.description,
+    i.url AS logo_url
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.id = $1 AND t.deleted_at IS NULL
+`
+
+type GetPublicTeamRow struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	LogoUrl     sql.NullString `json:"logo_url"`
+}
+
+func (q *Queries) GetPublicTeam(ctx context.Context, id int32) (GetPublicTeamRow, error) {
+	row := q.db.QueryRowContext(ctx, getPublicTeam, id)
+	var i GetPublicTeamRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.LogoUrl,
+	)
+	return i, err
+}
+
+const getPublicTeams = `-- name: GetPublicTeams :many
+SELECT
+    t.id,
+    t.name,
+    t.description,
+    i.url AS logo_url
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.deleted_at IS NULL AND t.id > $1
+ORDER BY t.id
+LIMIT $2
+`
+
+type GetPublicTeamsParams struct {
+	ID    int32 `json:"id"`
+	Limit int32 `json:"limit"`
+}
+
+type GetPublicTeamsRow struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	LogoUrl     sql.NullString `json:"logo_url"`
+}
+
+func (q *Queries) GetPublicTeams(ctx context.Context, arg GetPublicTeamsParams) ([]GetPublicTeamsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPublicTeams, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPublicTeamsRow{}
+	for rows.Next() {
+		var i GetPublicTeamsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.LogoUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -94,7 +326,7 @@ func (q *Queries) GetAllTeams(ctx context.Context, arg GetAllTeamsParams) ([]Tea
 }
 
 const getTeam = `-- name: GetTeam :one
-SELECT id, name, description, created_at, updated_at, deleted_at FROM teams WHERE id = $1 AND deleted_at IS NULL
+SELECT id, name, description, created_at, updated_at, deleted_at, logo_image_id, parent_id FROM teams WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetTeam(ctx context.Context, id int32) (Team, error) {
@@ -107,28 +339,150 @@ func (q *Queries) GetTeam(ctx context.Context, id int32) (Team, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LogoImageID,
+		&i.ParentID,
 	)
 	return i, err
 }
 
+const getTeamTree = `-- name: GetTeamTree :many
+SELECT
+    t.id,
+    t.name,
+    t.description,
+    t.parent_id,
+    i.url AS logo_url
+FROM teams t
+LEFT JOIN images i ON i.id = t.logo_image_id
+WHERE t.deleted_at IS NULL
+ORDER BY t.name, t.id
+`
+
+type GetTeamTreeRow struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	ParentID    sql.NullInt32  `json:"parent_id"`
+	LogoUrl     sql.NullString `json:"logo_url"`
+}
+
+func (q *Queries) GetTeamTree(ctx context.Context) ([]GetTeamTreeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTeamTree)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTeamTreeRow{}
+	for rows.Next() {
+		var i GetTeamTreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.LogoUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isTeamInSubtree = `-- name: IsTeamInSubtree :one
+WITH RECURSIVE subtree AS (
+    SELECT id FROM teams WHERE id = $2::int
+    UNION
+    SELECT t.id FROM teams t JOIN subtree s ON t.parent_id = s.id
+)
+SELECT EXISTS (
+    SELECT 1 FROM subtree WHERE id = $1::int
+)
+`
+
+type IsTeamInSubtreeParams struct {
+	TeamID int32 `json:"team_id"`
+	RootID int32 `json:"root_id"`
+}
+
+// reports whether team_id is root_id itself or one of its descendants
+func (q *Queries) IsTeamInSubtree(ctx context.Context, arg IsTeamInSubtreeParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isTeamInSubtree, arg.TeamID, arg.RootID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const lockTeamHierarchy = `-- name: LockTeamHierarchy :exec
+SELECT pg_advisory_xact_lock(hashtext('team_hierarchy'))
+`
+
+func (q *Queries) LockTeamHierarchy(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockTeamHierarchy)
+	return err
+}
+
 const updateTeam = `-- name: UpdateTeam :one
 UPDATE teams set
     name = $1,
     description = $2,
+    logo_image_id = $4,
     updated_at = NOW()
 WHERE
     id = $3
-RETURNING id, name, description, created_at, updated_at, deleted_at
+RETURNING id, name, description, created_at, updated_at, deleted_at, logo_image_id, parent_id
 `
 
 type UpdateTeamParams struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ID          int32  `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	ID          int32         `json:"id"`
+	LogoImageID sql.NullInt32 `json:"logo_image_id"`
 }
 
 func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, error) {
-	row := q.db.QueryRowContext(ctx, updateTeam, arg.Name, arg.Description, arg.ID)
+	row := q.db.QueryRowContext(ctx, updateTeam,
+		arg.Name,
+		arg.Description,
+		arg.ID,
+		arg.LogoImageID,
+	)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.LogoImageID,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const updateTeamParent = `-- name: UpdateTeamParent :one
+UPDATE teams set
+    parent_id = $2,
+    updated_at = NOW()
+WHERE
+    id = $1 AND deleted_at IS NULL
+RETURNING id, name, description, created_at, updated_at, deleted_at, logo_image_id, parent_id
+`
+
+type UpdateTeamParentParams struct {
+	ID       int32         `json:"id"`
+	ParentID sql.NullInt32 `json:"parent_id"`
+}
+
+func (q *Queries) UpdateTeamParent(ctx context.Context, arg UpdateTeamParentParams) (Team, error) {
+	row := q.db.QueryRowContext(ctx, updateTeamParent, arg.ID, arg.ParentID)
 	var i Team
 	err := row.Scan(
 		&i.ID,
@@ -137,6 +491,8 @@ func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.LogoImageID,
+		&i.ParentID,
 	)
 	return i, err
 }
@@ -0,0 +1,611 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: trash.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const getDeletedProject = `-- name: GetDeletedProject :one
+SELECT id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published FROM projects WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedProject(ctx context.Context, id int32) (Project, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
+	)
+	return i, err
+}
+
+const getDeletedProjectMembers = `-- name: GetDeletedProjectMembers :many
+SELECT
+    pu.id,
+    pu.project_id,
+    p.name AS project_name,
+    pu.user_id,
+    u.name,
+    u.last_name,
+    pu.role,
+    pu.deleted_at
+FROM project_users pu
+JOIN projects p ON p.id = pu.project_id
+JOIN users u ON u.id = pu.user_id
+WHERE pu.deleted_at IS NOT NULL
+ORDER BY pu.deleted_at DESC, pu.id
+LIMIT $1
+`
+
+type GetDeletedProjectMembersRow struct {
+	ID          int32        `json:"id"`
+	ProjectID   int32        `json:"project_id"`
+	ProjectName string       `json:"project_name"`
+	UserID      int32        `json:"user_id"`
+	Name        string       `json:"name"`
+	LastName    string       `json:"last_name"`
+	Role        string       `json:"role"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) GetDeletedProjectMembers(ctx context.Context, limit int32) ([]GetDeletedProjectMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedProjectMembers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDeletedProjectMembersRow{}
+	for rows.Next() {
+		var i GetDeletedProjectMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.ProjectName,
+			&i.UserID,
+			&i.Name,
+			&i.LastName,
+			&i.Role,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedProjects = `-- name: GetDeletedProjects :many
+SELECT id, name, description, deleted_at FROM projects
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id
+LIMIT $1
+`
+
+type GetDeletedProjectsRow struct {
+	ID          int32        `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) GetDeletedProjects(ctx context.Context, limit int32) ([]GetDeletedProjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedProjects, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDeletedProjectsRow{}
+	for rows.Next() {
+		var i GetDeletedProjectsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedTeam = `-- name: GetDeletedTeam :one
+SELECT id, name, description, created_at, updated_at, deleted_at, logo_image_id, parent_id FROM teams WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedTeam(ctx context.Context, id int32) (Team, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedTeam, id)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.LogoImageID,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getDeletedTeamMembers = `-- name: GetDeletedTeamMembers :many
+SELECT
+    tu.id,
+    tu.team_id,
+    t.name AS team_name,
+    tu.user_id,
+    u.name,
+    u.last_name,
+    tu.role,
+    tu.deleted_at
+FROM team_users tu
+JOIN teams t ON t.id = tu.team_id
+JOIN users u ON u.id = tu.user_id
+WHERE tu.deleted_at IS NOT NULL
+ORDER BY tu.deleted_at DESC, tu.id
+LIMIT $1
+`
+
+type GetDeletedTeamMembersRow struct {
+	ID        int32        `json:"id"`
+	TeamID    int32        `json:"team_id"`
+	TeamName  string       `json:"team_name"`
+	UserID    int32        `json:"user_id"`
+	Name      string       `json:"name"`
+	LastName  string       `json:"last_name"`
+	Role      string       `json:"role"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) GetDeletedTeamMembers(ctx context.Context, limit int32) ([]GetDeletedTeamMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedTeamMembers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDeletedTeamMembersRow{}
+	for rows.Next() {
+		var i GetDeletedTeamMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.TeamName,
+			&i.UserID,
+			&i.Name,
+			&i.LastName,
+			&i.Role,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedTeams = `-- name: GetDeletedTeams :many
+SELECT id, name, description, deleted_at FROM teams
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id
+LIMIT $1
+`
+
+type GetDeletedTeamsRow struct {
+	ID          int32        `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) GetDeletedTeams(ctx context.Context, limit int32) ([]GetDeletedTeamsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedTeams, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDeletedTeamsRow{}
+	for rows.Next() {
+		var i GetDeletedTeamsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedUser = `-- name: GetDeletedUser :one
+SELECT id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at FROM users WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LastName,
+		&i.Email,
+		&i.Password,
+		&i.TelephoneNumber,
+		&i.University,
+		&i.Department,
+		&i.DateOfBirth,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getDeletedUsers = `-- name: GetDeletedUsers :many
+SELECT id, name, last_name, email, deleted_at FROM users
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id
+LIMIT $1
+`
+
+type GetDeletedUsersRow struct {
+	ID        int32        `json:"id"`
+	Name      string       `json:"name"`
+	LastName  string       `json:"last_name"`
+	Email     string       `json:"email"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) GetDeletedUsers(ctx context.Context, limit int32) ([]GetDeletedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedUsers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDeletedUsersRow{}
+	for rows.Next() {
+		var i GetDeletedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LastName,
+			&i.Email,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeProjectMembers = `-- name: PurgeProjectMembers :execrows
+DELETE FROM project_users WHERE deleted_at < $1::timestamp
+`
+
+func (q *Queries) PurgeProjectMembers(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeProjectMembers, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeProjects = `-- name: PurgeProjects :execrows
+DELETE FROM projects WHERE deleted_at < $1::timestamp
+`
+
+func (q *Queries) PurgeProjects(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeProjects, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeTeamMembers = `-- name: PurgeTeamMembers :execrows
+DELETE FROM team_users WHERE deleted_at < $1::timestamp
+`
+
+func (q *Queries) PurgeTeamMembers(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeTeamMembers, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeTeamProjects = `-- name: PurgeTeamProjects :execrows
+DELETE FROM team_projects WHERE deleted_at < $1::timestamp
+`
+
+func (q *Queries) PurgeTeamProjects(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeTeamProjects, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeTeams = `-- name: PurgeTeams :execrows
+DELETE FROM teams WHERE deleted_at < $1::timestamp
+`
+
+func (q *Queries) PurgeTeams(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeTeams, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeUsers = `-- name: PurgeUsers :execrows
+DELETE FROM users u
+WHERE u.deleted_at < $1::timestamp
+    AND NOT EXISTS (SELECT 1 FROM project_positions pp WHERE pp.created_by = u.id)
+    AND NOT EXISTS (SELECT 1 FROM project_tasks pt WHERE pt.created_by = u.id)
+    AND NOT EXISTS (SELECT 1 FROM team_invitations ti WHERE ti.invited_by = u.id)
+    AND NOT EXISTS (SELECT 1 FROM announcements a WHERE a."authorId" = u.id)
+`
+
+// users who still authored positions, tasks, invitations or announcements stay in the trash
+func (q *Queries) PurgeUsers(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeUsers, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreProject = `-- name: RestoreProject :one
+UPDATE projects SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 RETURNING id, name, description, created_at, updated_at, deleted_at, created_by, approval_status, status, start_date, end_date, summary, tech_stack, repository_url, demo_url, cover_image_id, is_published
+`
+
+func (q *Queries) RestoreProject(ctx context.Context, id int32) (Project, error) {
+	row := q.db.QueryRowContext(ctx, restoreProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.CreatedBy,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Summary,
+		pq.Array(&i.TechStack),
+		&i.RepositoryUrl,
+		&i.DemoUrl,
+		&i.CoverImageID,
+		&i.IsPublished,
+	)
+	return i, err
+}
+
+const restoreProjectMember = `-- name: RestoreProjectMember :one
+UPDATE project_users pu SET deleted_at = NULL, updated_at = NOW()
+WHERE pu.id = $1 AND pu.deleted_at IS NOT NULL
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = pu.project_id AND p.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = pu.user_id AND u.deleted_at IS NULL)
+    AND NOT EXISTS (SELECT 1 FROM project_users a WHERE a.project_id = pu.project_id AND a.user_id = pu.user_id AND a.deleted_at IS NULL)
+RETURNING id, project_id, user_id, role, created_at, updated_at, deleted_at
+`
+
+func (q *Queries) RestoreProjectMember(ctx context.Context, id int32) (ProjectUser, error) {
+	row := q.db.QueryRowContext(ctx, restoreProjectMember, id)
+	var i ProjectUser
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const restoreProjectMembers = `-- name: RestoreProjectMembers :execrows
+UPDATE project_users pu SET deleted_at = NULL, updated_at = NOW()
+WHERE pu.deleted_at >= $1::timestamp
+    AND (pu.project_id = $2 OR pu.user_id = $3)
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = pu.project_id AND p.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = pu.user_id AND u.deleted_at IS NULL)
+    AND pu.id = (SELECT MAX(d.id) FROM project_users d WHERE d.project_id = pu.project_id AND d.user_id = pu.user_id)
+`
+
+type RestoreProjectMembersParams struct {
+	Since     time.Time     `json:"since"`
+	ProjectID sql.NullInt32 `json:"project_id"`
+	UserID    sql.NullInt32 `json:"user_id"`
+}
+
+// same rules as RestoreTeamMembers
+func (q *Queries) RestoreProjectMembers(ctx context.Context, arg RestoreProjectMembersParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreProjectMembers, arg.Since, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTeam = `-- name: RestoreTeam :one
+UPDATE teams SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 RETURNING id, name, description, created_at, updated_at, deleted_at, logo_image_id, parent_id
+`
+
+func (q *Queries) RestoreTeam(ctx context.Context, id int32) (Team, error) {
+	row := q.db.QueryRowContext(ctx, restoreTeam, id)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.LogoImageID,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const restoreTeamMember = `-- name: RestoreTeamMember :one
+UPDATE team_users tu SET deleted_at = NULL, updated_at = NOW()
+WHERE tu.id = $1 AND tu.deleted_at IS NOT NULL
+    AND EXISTS (SELECT 1 FROM teams t WHERE t.id = tu.team_id AND t.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = tu.user_id AND u.deleted_at IS NULL)
+    AND NOT EXISTS (SELECT 1 FROM team_users a WHERE a.team_id = tu.team_id AND a.user_id = tu.user_id AND a.deleted_at IS NULL)
+RETURNING id, team_id, user_id, role, created_at, updated_at, deleted_at
+`
+
+func (q *Queries) RestoreTeamMember(ctx context.Context, id int32) (TeamUser, error) {
+	row := q.db.QueryRowContext(ctx, restoreTeamMember, id)
+	var i TeamUser
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const restoreTeamMembers = `-- name: RestoreTeamMembers :execrows
+UPDATE team_users tu SET deleted_at = NULL, updated_at = NOW()
+WHERE tu.deleted_at >= $1::timestamp
+    AND (tu.team_id = $2 OR tu.user_id = $3)
+    AND EXISTS (SELECT 1 FROM teams t WHERE t.id = tu.team_id AND t.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM users u WHERE u.id = tu.user_id AND u.deleted_at IS NULL)
+    AND tu.id = (SELECT MAX(d.id) FROM team_users d WHERE d.team_id = tu.team_id AND d.user_id = tu.user_id)
+`
+
+type RestoreTeamMembersParams struct {
+	Since  time.Time     `json:"since"`
+	TeamID sql.NullInt32 `json:"team_id"`
+	UserID sql.NullInt32 `json:"user_id"`
+}
+
+// memberships are only revived when they were deleted together with or after the restored entity,
+// the other side is still alive and the row is the latest one of its pair
+func (q *Queries) RestoreTeamMembers(ctx context.Context, arg RestoreTeamMembersParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreTeamMembers, arg.Since, arg.TeamID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTeamProjects = `-- name: RestoreTeamProjects :execrows
+UPDATE team_projects tp SET deleted_at = NULL, updated_at = NOW()
+WHERE tp.deleted_at >= $1::timestamp
+    AND (tp.team_id = $2 OR tp.project_id = $3)
+    AND EXISTS (SELECT 1 FROM teams t WHERE t.id = tp.team_id AND t.deleted_at IS NULL)
+    AND EXISTS (SELECT 1 FROM projects p WHERE p.id = tp.project_id AND p.deleted_at IS NULL)
+    AND tp.id = (SELECT MAX(d.id) FROM team_projects d WHERE d.team_id = tp.team_id AND d.project_id = tp.project_id)
+`
+
+type RestoreTeamProjectsParams struct {
+	Since     time.Time     `json:"since"`
+	TeamID    sql.NullInt32 `json:"team_id"`
+	ProjectID sql.NullInt32 `json:"project_id"`
+}
+
+// same rules as RestoreTeamMembers
+func (q *Queries) RestoreTeamProjects(ctx context.Context, arg RestoreTeamProjectsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreTeamProjects, arg.Since, arg.TeamID, arg.ProjectID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreUser = `-- name: RestoreUser :one
+UPDATE users SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 RETURNING id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at
+`
+
+func (q *Queries) RestoreUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, restoreUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LastName,
+		&i.Email,
+		&i.Password,
+		&i.TelephoneNumber,
+		&i.University,
+		&i.Department,
+		&i.DateOfBirth,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
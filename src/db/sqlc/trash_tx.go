@@ -0,0 +1,183 @@
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type RestoreTeamTxResult struct {
+	Team            Team  `json:"team"`
+	RestoredMembers int64 `json:"restored_members"`
+	RestoredLinks   int64 `json:"restored_links"`
+}
+
+// RestoreTeamTx revives a soft-deleted team with the memberships and project links that were deleted with it.
+// It returns sql.ErrNoRows if the team is not in the trash.
+func (store *Store) RestoreTeamTx(ctx context.Context, teamID int32) (RestoreTeamTxResult, error) {
+	var result RestoreTeamTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		deleted, err := q.GetDeletedTeam(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		result.Team, err = q.RestoreTeam(ctx, teamID)
+		if err != nil {
+			return err
+		}
+
+		id := sql.NullInt32{Int32: teamID, Valid: true}
+
+		result.RestoredMembers, err = q.RestoreTeamMembers(ctx, RestoreTeamMembersParams{
+			Since:  deleted.DeletedAt.Time,
+			TeamID: id,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.RestoredLinks, err = q.RestoreTeamProjects(ctx, RestoreTeamProjectsParams{
+			Since:  deleted.DeletedAt.Time,
+			TeamID: id,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type RestoreProjectTxResult struct {
+	Project         Project `json:"project"`
+	RestoredMembers int64   `json:"restored_members"`
+	RestoredLinks   int64   `json:"restored_links"`
+}
+
+// RestoreProjectTx revives a soft-deleted project with the memberships and team links that were deleted with it.
+// It returns sql.ErrNoRows if the project is not in the trash.
+func (store *Store) RestoreProjectTx(ctx context.Context, projectID int32) (RestoreProjectTxResult, error) {
+	var result RestoreProjectTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		deleted, err := q.GetDeletedProject(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		result.Project, err = q.RestoreProject(ctx, projectID)
+		if err != nil {
+			return err
+		}
+
+		id := sql.NullInt32{Int32: projectID, Valid: true}
+
+		result.RestoredMembers, err = q.RestoreProjectMembers(ctx, RestoreProjectMembersParams{
+			Since:     deleted.DeletedAt.Time,
+			ProjectID: id,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.RestoredLinks, err = q.RestoreTeamProjects(ctx, RestoreTeamProjectsParams{
+			Since:     deleted.DeletedAt.Time,
+			ProjectID: id,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type RestoreUserTxResult struct {
+	User                   User  `json:"user"`
+	RestoredTeamMembers    int64 `json:"restored_team_members"`
+	RestoredProjectMembers int64 `json:"restored_project_members"`
+}
+
+// RestoreUserTx revives a soft-deleted user with the team and project memberships that were deleted with them.
+// It returns sql.ErrNoRows if the user is not in the trash.
+func (store *Store) RestoreUserTx(ctx context.Context, userID int32) (RestoreUserTxResult, error) {
+	var result RestoreUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		deleted, err := q.GetDeletedUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.RestoreUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		id := sql.NullInt32{Int32: userID, Valid: true}
+
+		result.RestoredTeamMembers, err = q.RestoreTeamMembers(ctx, RestoreTeamMembersParams{
+			Since:  deleted.DeletedAt.Time,
+			UserID: id,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.RestoredProjectMembers, err = q.RestoreProjectMembers(ctx, RestoreProjectMembersParams{
+			Since:  deleted.DeletedAt.Time,
+			UserID: id,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type PurgeTrashTxResult struct {
+	TeamMembers    int64 `json:"team_members"`
+	ProjectMembers int64 `json:"project_members"`
+	TeamProjects   int64 `json:"team_projects"`
+	Teams          int64 `json:"teams"`
+	Projects       int64 `json:"projects"`
+	Users          int64 `json:"users"`
+}
+
+// PurgeTrashTx hard-deletes everything that was soft-deleted before the given time.
+// Rows that belong to a purged team, project or user are removed by the ON DELETE rules of their foreign keys.
+func (store *Store) PurgeTrashTx(ctx context.Context, before time.Time) (PurgeTrashTxResult, error) {
+	var result PurgeTrashTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.TeamMembers, err = q.PurgeTeamMembers(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		result.ProjectMembers, err = q.PurgeProjectMembers(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		result.TeamProjects, err = q.PurgeTeamProjects(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		result.Teams, err = q.PurgeTeams(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		result.Projects, err = q.PurgeProjects(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		// users go last so the positions and tasks of purged projects no longer keep them
+		result.Users, err = q.PurgeUsers(ctx, before)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,59 @@
+package sqlc
+
+import "context"
+
+// DeleteUserTx soft-deletes a user together with their team and project memberships.
+// All rows share the deletion time, so RestoreUserTx can bring the memberships back.
+// It returns ErrLastLead if the user is the only lead of a team or project and sql.ErrNoRows if the user does not exist.
+func (store *Store) DeleteUserTx(ctx context.Context, userID int32) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetUserWithNoDetails(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		teamLeads, err := q.LockTeamLeadsOfUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		leadsByTeam := make(map[int32][]int32)
+		for _, lead := range teamLeads {
+			leadsByTeam[lead.TeamID] = append(leadsByTeam[lead.TeamID], lead.UserID)
+		}
+
+		for _, leadIDs := range leadsByTeam {
+			if isOnlyLead(leadIDs, userID) {
+				return ErrLastLead
+			}
+		}
+
+		projectLeads, err := q.LockProjectLeadsOfUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		leadsByProject := make(map[int32][]int32)
+		for _, lead := range projectLeads {
+			leadsByProject[lead.ProjectID] = append(leadsByProject[lead.ProjectID], lead.UserID)
+		}
+
+		for _, leadIDs := range leadsByProject {
+			if isOnlyLead(leadIDs, userID) {
+				return ErrLastLead
+			}
+		}
+
+		err = q.DeleteTeamMemberByUserId(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteProjectMemberByUserId(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteUser(ctx, userID)
+	})
+}
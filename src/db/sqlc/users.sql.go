@@ -12,7 +12,9 @@ import (
 
 const checkUserIfExistByEmail = `-- name: CheckUserIfExistByEmail :one
 SELECT id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at FROM users
-WHERE email = $1
+WHERE lower(email) = lower($1)
+ORDER BY deleted_at IS NULL DESC, id DESC
+LIMIT 1
 `
 
 func (q *Queries) CheckUserIfExistByEmail(ctx context.Context, email string) (User, error) {
@@ -36,6 +38,17 @@ func (q *Queries) CheckUserIfExistByEmail(ctx context.Context, email string) (Us
 	return i, err
 }
 
+const countUsers = `-- name: CountUsers :one
+Select COUNT(*) from users where deleted_at is null
+`
+
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     name,
@@ -111,7 +124,7 @@ const deleteUser = `-- name: DeleteUser :exec
 UPDATE users SET
     deleted_at = NOW()
 WHERE
-    id = $1
+    id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
@@ -120,7 +133,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
 }
 
 const getAllUsers = `-- name: GetAllUsers :many
-Select id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at from users where deleted_at is null LIMIT $1 OFFSET $2
+Select id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at from users where deleted_at is null ORDER BY id LIMIT $1 OFFSET $2
 `
 
 type GetAllUsersParams struct {
@@ -165,6 +178,52 @@ func (q *Queries) GetAllUsers(ctx context.Context, arg GetAllUsersParams) ([]Use
 	return items, nil
 }
 
+const getAllUsersAfterCursor = `-- name: GetAllUsersAfterCursor :many
+Select id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at from users where deleted_at is null AND id > $1 ORDER BY id LIMIT $2
+`
+
+type GetAllUsersAfterCursorParams struct {
+	ID    int32 `json:"id"`
+	Limit int32 `json:"limit"`
+}
+
+func (q *Queries) GetAllUsersAfterCursor(ctx context.Context, arg GetAllUsersAfterCursorParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getAllUsersAfterCursor, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.LastName,
+			&i.Email,
+			&i.Password,
+			&i.TelephoneNumber,
+			&i.University,
+			&i.Department,
+			&i.DateOfBirth,
+			&i.Role,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUser = `-- name: GetUser :one
 SELECT id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at from users where id  = $1 and deleted_at is null
 `
@@ -215,7 +274,7 @@ LEFT JOIN
 LEFT JOIN
     projects p on up.project_id = p.id
 WHERE
-    u.email = $1 AND u.deleted_at IS NULL
+    lower(u.email) = lower($1) AND u.deleted_at IS NULL
 GROUP BY
 		u.id, u.email
 `
@@ -405,3 +464,38 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 	)
 	return i, err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users SET
+    password = $1,
+    updated_at = NOW()
+WHERE
+    lower(email) = lower($2) AND deleted_at IS NULL
+returning id, name, last_name, email, password, telephone_number, university, department, date_of_birth, role, created_at, updated_at, deleted_at
+`
+
+type UpdateUserPasswordParams struct {
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserPassword, arg.Password, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.LastName,
+		&i.Email,
+		&i.Password,
+		&i.TelephoneNumber,
+		&i.University,
+		&i.Department,
+		&i.DateOfBirth,
+		&i.Role,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter allows limit hits per key in a fixed window
+type Limiter struct {
+	store  Store
+	prefix string
+	limit  int64
+	window time.Duration
+}
+
+func NewLimiter(store Store, prefix string, limit int64, window time.Duration) *Limiter {
+	return &Limiter{
+		store:  store,
+		prefix: prefix,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Allow counts a hit for key and reports whether it is within the limit, and otherwise when the window resets
+func (l *Limiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	count, ttl, err := l.store.Incr(ctx, l.prefix+key, l.window)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if count > l.limit {
+		return false, ttl, nil
+	}
+
+	return true, 0, nil
+}
+
+////////////////////////
+
+// LOCKOUT
+// Lockout locks an account after threshold failed attempts, every further failure doubles the lock up to maxDuration.
+// Failures are forgotten after a success or resetAfter after the first one
+type Lockout struct {
+	store       Store
+	threshold   int64
+	duration    time.Duration
+	maxDuration time.Duration
+	resetAfter  time.Duration
+}
+
+func NewLockout(store Store, threshold int64, duration, maxDuration, resetAfter time.Duration) *Lockout {
+	return &Lockout{
+		store:       store,
+		threshold:   threshold,
+		duration:    duration,
+		maxDuration: maxDuration,
+		resetAfter:  resetAfter,
+	}
+}
+
+// Locked returns how long the account stays locked, 0 when it is not
+func (l *Lockout) Locked(ctx context.Context, account string) (time.Duration, error) {
+	return l.store.TTL(ctx, lockKey(account))
+}
+
+// Fail records a failed attempt and returns how long the account is locked because of it
+func (l *Lockout) Fail(ctx context.Context, account string) (time.Duration, error) {
+	failures, _, err := l.store.Incr(ctx, failuresKey(account), l.resetAfter)
+	if err != nil {
+		return 0, err
+	}
+
+	if failures < l.threshold {
+		return 0, nil
+	}
+
+	lock := l.duration
+
+	for i := l.threshold; i < failures && lock < l.maxDuration; i++ {
+		lock *= 2
+	}
+
+	lock = min(lock, l.maxDuration)
+
+	return lock, l.store.Set(ctx, lockKey(account), lock)
+}
+
+// Reset forgets the failures of an account after a successful attempt
+func (l *Lockout) Reset(ctx context.Context, account string) error {
+	return l.store.Delete(ctx, failuresKey(account), lockKey(account))
+}
+
+func failuresKey(account string) string {
+	return "lockout:failures:" + account
+}
+
+func lockKey(account string) string {
+	return "lockout:lock:" + account
+}
@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"yildizskylab/src/util"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Store keeps counters that expire, backed by redis it is shared by every replica
+type Store interface {
+	// Incr adds one to key and returns the new count and how long the key lives,
+	// a new key expires ttl after its first hit
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, time.Duration, error)
+	// TTL returns how long key lives, 0 when it does not exist
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	// Set creates key with ttl, replacing the key when it exists
+	Set(ctx context.Context, key string, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Close() error
+}
+
+// NewStore returns the store of the configured backend
+func NewStore(config util.Config) (Store, error) {
+	switch config.RateLimitBackend {
+	case BackendMemory:
+		return NewMemoryStore(), nil
+	case BackendRedis:
+		options, err := redis.ParseURL(config.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+
+		return &RedisStore{client: redis.NewClient(options)}, nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", config.RateLimitBackend)
+	}
+}
+
+////////////////////////
+
+// MEMORY STORE
+// sweepInterval is how often expired keys are dropped so the map does not grow with every ip seen
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	count   int64
+	expires time.Time
+}
+
+// MemoryStore only limits a single replica, it is meant for development and single instance deployments
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries:   make(map[string]memoryEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *MemoryStore) Incr(_ context.Context, key string, ttl time.Duration) (int64, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	entry, ok := m.entries[key]
+
+	if !ok || !now.Before(entry.expires) {
+		entry = memoryEntry{expires: now.Add(ttl)}
+	}
+
+	entry.count++
+	m.entries[key] = entry
+
+	return entry.count, entry.expires.Sub(now), nil
+}
+
+func (m *MemoryStore) TTL(_ context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return 0, nil
+	}
+
+	return max(time.Until(entry.expires), 0), nil
+}
+
+func (m *MemoryStore) Set(_ context.Context, key string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = memoryEntry{count: 1, expires: time.Now().Add(ttl)}
+
+	return nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// sweep has to be called with the lock held
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, entry := range m.entries {
+		if !now.Before(entry.expires) {
+			delete(m.entries, key)
+		}
+	}
+
+	m.lastSweep = now
+}
+
+////////////////////////
+
+// REDIS STORE
+// incrScript sets the expiry only on the first hit, so a window is not extended by every request
+var incrScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// RedisStore works with redis and compatible servers like valkey or dragonfly
+type RedisStore struct {
+	client *redis.Client
+}
+
+func (r *RedisStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, time.Duration, error) {
+	result, err := incrScript.Run(ctx, r.client, []string{key}, ttl.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return result[0], time.Duration(result[1]) * time.Millisecond, nil
+}
+
+func (r *RedisStore) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// redis answers with a negative ttl for missing keys
+	return max(ttl, 0), nil
+}
+
+func (r *RedisStore) Set(ctx context.Context, key string, ttl time.Duration) error {
+	return r.client.Set(ctx, key, 1, ttl).Err()
+}
+
+func (r *RedisStore) Delete(ctx context.Context, keys ...string) error {
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
@@ -1,6 +1,20 @@
 package util
 
-import "github.com/spf13/viper"
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// minSecretLength keeps the jwt signing key long enough for HS256
+const minSecretLength = 32
 
 type Config struct {
 	DBDriver      string `mapstructure:"DB_DRIVER"`
@@ -8,8 +22,67 @@ type Config struct {
 	ServerAddress string `mapstructure:"SERVER_ADDRESS"`
 	Secret        string `mapstructure:"SECRET"`
 	Domain        string `mapstructure:"DOMAIN"`
+	// the timeouts bound how long a slow client can hold a connection
+	ReadHeaderTimeout time.Duration `mapstructure:"READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `mapstructure:"READ_TIMEOUT"`
+	WriteTimeout      time.Duration `mapstructure:"WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `mapstructure:"IDLE_TIMEOUT"`
+	// ShutdownTimeout is how long the requests in flight may take to finish after SIGTERM or SIGINT
+	ShutdownTimeout time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+	MaxHeaderBytes  int           `mapstructure:"MAX_HEADER_BYTES"`
+	// MaxBodySize is the largest accepted request body in bytes, it has to leave room for an image upload
+	MaxBodySize int64 `mapstructure:"MAX_BODY_SIZE"`
+	// MetricsAddress serves /metrics on its own listener, usually only reachable from inside the network
+	MetricsAddress string `mapstructure:"METRICS_ADDRESS"`
+	// MetricsToken is the bearer token scrapers have to send, without it and MetricsAddress /metrics is not served
+	MetricsToken string `mapstructure:"METRICS_TOKEN"`
+	// TraceExporter is none, stdout for local debugging or otlp, which reads the standard OTEL_EXPORTER_OTLP_* variables
+	TraceExporter    string  `mapstructure:"TRACE_EXPORTER"`
+	TraceSampleRatio float64 `mapstructure:"TRACE_SAMPLE_RATIO"`
+	ServiceName      string  `mapstructure:"SERVICE_NAME"`
+	// TrustedProxies are the proxies whose X-Forwarded-For is believed, the rate limits count the address they report.
+	// Only loopback is trusted by default, a private range would also cover the docker gateway every published port goes through
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+	// RateLimitBackend is memory for a single replica or redis, which also works with compatible servers
+	RateLimitBackend string `mapstructure:"RATE_LIMIT_BACKEND"`
+	RedisURL         string `mapstructure:"REDIS_URL"`
+	// AuthIPLimit and AuthAccountLimit are the login and signup attempts allowed per AuthLimitWindow
+	AuthIPLimit      int64         `mapstructure:"AUTH_IP_LIMIT"`
+	AuthAccountLimit int64         `mapstructure:"AUTH_ACCOUNT_LIMIT"`
+	AuthLimitWindow  time.Duration `mapstructure:"AUTH_LIMIT_WINDOW"`
+	// LockoutThreshold failed logins lock an account for LockoutDuration, every further failure doubles it up to LockoutMaxDuration
+	LockoutThreshold   int64         `mapstructure:"LOCKOUT_THRESHOLD"`
+	LockoutDuration    time.Duration `mapstructure:"LOCKOUT_DURATION"`
+	LockoutMaxDuration time.Duration `mapstructure:"LOCKOUT_MAX_DURATION"`
+	// LockoutResetAfter is how long failed logins are remembered
+	LockoutResetAfter time.Duration `mapstructure:"LOCKOUT_RESET_AFTER"`
+	// LogLevel is one of debug, info, warn or error
+	LogLevel string `mapstructure:"LOG_LEVEL"`
+	// ProjectCreationPolicy is one of anyone, team_leads, admins or approval
+	ProjectCreationPolicy string `mapstructure:"PROJECT_CREATION_POLICY"`
+	// TrashRetention is how long soft-deleted rows are kept before they are purged, 0 keeps them forever
+	TrashRetention time.Duration `mapstructure:"TRASH_RETENTION"`
+	// AutoMigrate applies the pending migrations before the server starts
+	AutoMigrate bool `mapstructure:"AUTO_MIGRATE"`
+	// CORSAllowOrigins is a comma separated list of the frontends allowed to call the api,
+	// a single * matches any part of an origin like https://*.preview.example.com
+	CORSAllowOrigins     []string `mapstructure:"CORS_ALLOW_ORIGINS"`
+	CORSAllowMethods     []string `mapstructure:"CORS_ALLOW_METHODS"`
+	CORSAllowHeaders     []string `mapstructure:"CORS_ALLOW_HEADERS"`
+	CORSAllowCredentials bool     `mapstructure:"CORS_ALLOW_CREDENTIALS"`
+	// CORSPublicAllowOrigins is used for reading public routes like /images and /news, credentials are never allowed there
+	CORSPublicAllowOrigins []string `mapstructure:"CORS_PUBLIC_ALLOW_ORIGINS"`
+	// AccessTokenDuration is how long a login token stays valid
+	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	// InvitationDuration is how long a team invitation link stays valid
+	InvitationDuration time.Duration `mapstructure:"INVITATION_DURATION"`
+	// MaxUploadSize is the largest accepted image upload in bytes
+	MaxUploadSize int64 `mapstructure:"MAX_UPLOAD_SIZE"`
+	// UploadAllowedTypes is a comma separated list of the accepted image content types
+	UploadAllowedTypes []string `mapstructure:"UPLOAD_ALLOWED_TYPES"`
 }
 
+// LoadConfig reads app.env from path when it exists, environment variables always override it
 func LoadConfig(path string) (config Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigName("app")
@@ -17,11 +90,195 @@ func LoadConfig(path string) (config Config, err error) {
 
 	viper.AutomaticEnv()
 
+	viper.SetDefault("DB_DRIVER", "postgres")
+	viper.SetDefault("SERVER_ADDRESS", "0.0.0.0:8080")
+	viper.SetDefault("READ_HEADER_TIMEOUT", "10s")
+	viper.SetDefault("READ_TIMEOUT", "60s")
+	viper.SetDefault("WRITE_TIMEOUT", "60s")
+	viper.SetDefault("IDLE_TIMEOUT", "120s")
+	viper.SetDefault("SHUTDOWN_TIMEOUT", "30s")
+	viper.SetDefault("MAX_HEADER_BYTES", 1<<20)
+	viper.SetDefault("MAX_BODY_SIZE", 6<<20)
+	viper.SetDefault("TRUSTED_PROXIES", "127.0.0.1,::1")
+	viper.SetDefault("RATE_LIMIT_BACKEND", "memory")
+	viper.SetDefault("AUTH_IP_LIMIT", 20)
+	viper.SetDefault("AUTH_ACCOUNT_LIMIT", 10)
+	viper.SetDefault("AUTH_LIMIT_WINDOW", "1m")
+	viper.SetDefault("LOCKOUT_THRESHOLD", 5)
+	viper.SetDefault("LOCKOUT_DURATION", "1m")
+	viper.SetDefault("LOCKOUT_MAX_DURATION", "1h")
+	viper.SetDefault("LOCKOUT_RESET_AFTER", "24h")
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("TRACE_EXPORTER", "none")
+	viper.SetDefault("TRACE_SAMPLE_RATIO", 1)
+	viper.SetDefault("SERVICE_NAME", "yildizskylab")
+	viper.SetDefault("PROJECT_CREATION_POLICY", "anyone")
+	viper.SetDefault("TRASH_RETENTION", "720h")
+	viper.SetDefault("AUTO_MIGRATE", false)
+	viper.SetDefault("CORS_ALLOW_ORIGINS", "http://localhost:3000")
+	viper.SetDefault("CORS_ALLOW_METHODS", "GET,POST,PUT,DELETE,OPTIONS")
+	viper.SetDefault("CORS_ALLOW_HEADERS", "Origin,Content-Type,Authorization")
+	viper.SetDefault("CORS_ALLOW_CREDENTIALS", true)
+	viper.SetDefault("CORS_PUBLIC_ALLOW_ORIGINS", "*")
+	viper.SetDefault("ACCESS_TOKEN_DURATION", "24h")
+	viper.SetDefault("INVITATION_DURATION", "168h")
+	viper.SetDefault("MAX_UPLOAD_SIZE", 5<<20)
+	viper.SetDefault("UPLOAD_ALLOWED_TYPES", "image/jpeg,image/png,image/gif,image/webp")
+
+	// without app.env viper only knows the keys it was told about, so every field is bound to its env variable
+	configType := reflect.TypeOf(config)
+	for i := 0; i < configType.NumField(); i++ {
+		if err = viper.BindEnv(configType.Field(i).Tag.Get("mapstructure")); err != nil {
+			return Config{}, err
+		}
+	}
+
 	err = viper.ReadInConfig()
 	if err != nil {
-		return Config{}, err
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return Config{}, err
+		}
 	}
 
 	err = viper.Unmarshal(&config)
-	return
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.Domain = strings.TrimSuffix(config.Domain, "/")
+
+	return config, config.Validate()
+}
+
+// Validate reports every missing or malformed setting at once
+func (config Config) Validate() error {
+	var errs []error
+
+	if config.DBDriver == "" {
+		errs = append(errs, errors.New("DB_DRIVER is required"))
+	}
+
+	if config.DBSource == "" {
+		errs = append(errs, errors.New("DB_SOURCE is required"))
+	}
+
+	if config.ServerAddress == "" {
+		errs = append(errs, errors.New("SERVER_ADDRESS is required"))
+	}
+
+	if len(config.Secret) < minSecretLength {
+		errs = append(errs, fmt.Errorf("SECRET must be at least %d characters", minSecretLength))
+	}
+
+	if domain, err := url.Parse(config.Domain); err != nil || (domain.Scheme != "http" && domain.Scheme != "https") || domain.Host == "" {
+		errs = append(errs, errors.New("DOMAIN must be an absolute http or https url"))
+	}
+
+	for _, timeout := range []struct {
+		key   string
+		value time.Duration
+	}{
+		{"READ_HEADER_TIMEOUT", config.ReadHeaderTimeout},
+		{"READ_TIMEOUT", config.ReadTimeout},
+		{"WRITE_TIMEOUT", config.WriteTimeout},
+		{"IDLE_TIMEOUT", config.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", config.ShutdownTimeout},
+		{"AUTH_LIMIT_WINDOW", config.AuthLimitWindow},
+		{"LOCKOUT_DURATION", config.LockoutDuration},
+		{"LOCKOUT_MAX_DURATION", config.LockoutMaxDuration},
+		{"LOCKOUT_RESET_AFTER", config.LockoutResetAfter},
+	} {
+		if timeout.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", timeout.key))
+		}
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(config.LogLevel)); err != nil {
+		errs = append(errs, errors.New("LOG_LEVEL must be one of debug, info, warn or error"))
+	}
+
+	if !slices.Contains([]string{"none", "stdout", "otlp"}, config.TraceExporter) {
+		errs = append(errs, errors.New("TRACE_EXPORTER must be one of none, stdout or otlp"))
+	}
+
+	if config.TraceSampleRatio < 0 || config.TraceSampleRatio > 1 {
+		errs = append(errs, errors.New("TRACE_SAMPLE_RATIO must be between 0 and 1"))
+	}
+
+	switch config.RateLimitBackend {
+	case "memory":
+	case "redis":
+		if config.RedisURL == "" {
+			errs = append(errs, errors.New("REDIS_URL is required when RATE_LIMIT_BACKEND is redis"))
+		}
+	default:
+		errs = append(errs, errors.New("RATE_LIMIT_BACKEND must be memory or redis"))
+	}
+
+	if config.AuthIPLimit <= 0 || config.AuthAccountLimit <= 0 || config.LockoutThreshold <= 0 {
+		errs = append(errs, errors.New("AUTH_IP_LIMIT, AUTH_ACCOUNT_LIMIT and LOCKOUT_THRESHOLD must be positive"))
+	}
+
+	if config.LockoutMaxDuration < config.LockoutDuration {
+		errs = append(errs, errors.New("LOCKOUT_MAX_DURATION can not be shorter than LOCKOUT_DURATION"))
+	}
+
+	if config.MaxHeaderBytes <= 0 {
+		errs = append(errs, errors.New("MAX_HEADER_BYTES must be positive"))
+	}
+
+	if len(config.CORSAllowOrigins) == 0 {
+		errs = append(errs, errors.New("CORS_ALLOW_ORIGINS needs at least one origin"))
+	}
+
+	for _, origin := range slices.Concat(config.CORSAllowOrigins, config.CORSPublicAllowOrigins) {
+		if strings.Count(origin, "*") > 1 {
+			errs = append(errs, fmt.Errorf("cors origin %q can only contain one *", origin))
+		}
+	}
+
+	// browsers reject credentials for a bare * origin, the frontends have to be listed
+	if config.CORSAllowCredentials && slices.Contains(config.CORSAllowOrigins, "*") {
+		errs = append(errs, errors.New("CORS_ALLOW_ORIGINS can not be * when CORS_ALLOW_CREDENTIALS is true"))
+	}
+
+	if len(config.CORSAllowMethods) == 0 {
+		errs = append(errs, errors.New("CORS_ALLOW_METHODS needs at least one method"))
+	}
+
+	if len(config.CORSPublicAllowOrigins) == 0 {
+		errs = append(errs, errors.New("CORS_PUBLIC_ALLOW_ORIGINS needs at least one origin"))
+	}
+
+	if !slices.Contains([]string{"anyone", "team_leads", "admins", "approval"}, config.ProjectCreationPolicy) {
+		errs = append(errs, errors.New("PROJECT_CREATION_POLICY must be one of anyone, team_leads, admins or approval"))
+	}
+
+	if config.TrashRetention < 0 {
+		errs = append(errs, errors.New("TRASH_RETENTION can not be negative"))
+	}
+
+	if config.AccessTokenDuration <= 0 {
+		errs = append(errs, errors.New("ACCESS_TOKEN_DURATION must be positive"))
+	}
+
+	if config.InvitationDuration <= 0 {
+		errs = append(errs, errors.New("INVITATION_DURATION must be positive"))
+	}
+
+	if config.MaxUploadSize <= 0 {
+		errs = append(errs, errors.New("MAX_UPLOAD_SIZE must be positive"))
+	}
+
+	if config.MaxBodySize <= config.MaxUploadSize {
+		errs = append(errs, errors.New("MAX_BODY_SIZE must be larger than MAX_UPLOAD_SIZE"))
+	}
+
+	if len(config.UploadAllowedTypes) == 0 {
+		errs = append(errs, errors.New("UPLOAD_ALLOWED_TYPES needs at least one content type"))
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,9 @@
+package util
+
+// build information, set at build time with
+// -ldflags "-X yildizskylab/src/util.Version=... -X yildizskylab/src/util.Commit=... -X yildizskylab/src/util.BuildTime=..."
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)